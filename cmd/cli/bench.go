@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"wordle/solver"
+	"wordle/wordle"
+)
+
+// maxGuesses is how many guesses Wordle allows before the game is lost.
+const maxGuesses = 6
+
+// benchResult is the outcome of playing one answer.
+type benchResult struct {
+	game solver.Game
+	err  error
+}
+
+// runBench plays every answer with the selected strategy and opener and
+// reports how many guesses each game took.
+func runBench(args []string, getenv func(string) string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strategyName := flags.String("strategy", getenv("WORDLE_STRATEGY"), "guess ranking strategy: entropy, minimax or expected")
+	opener := flags.String("opener", "", "first guess for every game (default: the strategy's best guess)")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of games to play at once")
+	limit := flags.Int("limit", 0, "only play the first n answers (0 plays them all)")
+	verbose := flags.Bool("v", false, "print every game")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *workers < 1 {
+		return fmt.Errorf("workers must be at least 1")
+	}
+
+	strategy, err := wordle.StrategyByName(*strategyName)
+	if err != nil {
+		return err
+	}
+
+	lists, err := loadLists(getenv, stderr)
+	if err != nil {
+		return err
+	}
+	if *opener != "" && !slices.Contains(lists.Guesses, *opener) {
+		return fmt.Errorf("opener %q is not in the guess list", *opener)
+	}
+
+	answers := lists.Answers
+	if *limit > 0 && *limit < len(answers) {
+		answers = answers[:*limit]
+	}
+
+	s := &solver.Solver{
+		Answers:  lists.Answers,
+		Guesses:  lists.Guesses,
+		Strategy: wordle.WithPrior(strategy, lists.Frequencies),
+		Opener:   *opener,
+	}
+
+	start := time.Now()
+	results := playAll(s, answers, *workers)
+	elapsed := time.Since(start)
+
+	if *verbose {
+		for _, r := range results {
+			printGame(stdout, r)
+		}
+	}
+	return printBench(stdout, strategy.Name(), *opener, results, elapsed)
+}
+
+// playAll plays every answer on a pool of workers and returns the results in
+// the same order as answers.
+func playAll(s *solver.Solver, answers []string, workers int) []benchResult {
+	results := make([]benchResult, len(answers))
+	if len(answers) == 0 {
+		return results
+	}
+
+	// Every game starts with the same guess, and ranking it is the slowest
+	// step, so play one game first and let the others find it in the cache
+	// rather than have every worker rank it at once
+	game, err := s.Play(answers[0])
+	results[0] = benchResult{game: game, err: err}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				game, err := s.Play(answers[i])
+				results[i] = benchResult{game: game, err: err}
+			}
+		}()
+	}
+	for i := 1; i < len(answers); i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func printGame(stdout io.Writer, r benchResult) {
+	var guesses []string
+	for _, turn := range r.game.Turns {
+		guesses = append(guesses, fmt.Sprintf("%s(%s,%d)", turn.Guess, turn.Pattern, turn.Remaining))
+	}
+	_, _ = fmt.Fprintf(stdout, "%s %d: %s\n", r.game.Answer, len(r.game.Turns), strings.Join(guesses, " "))
+	if r.err != nil {
+		_, _ = fmt.Fprintf(stdout, "  error: %s\n", r.err)
+	}
+}
+
+func printBench(stdout io.Writer, strategy, opener string, results []benchResult, elapsed time.Duration) error {
+	distribution := make(map[int]int)
+	var total, worst, failures, unplayed int
+	for _, r := range results {
+		if r.err != nil {
+			unplayed++
+			continue
+		}
+		n := len(r.game.Turns)
+		distribution[n]++
+		total += n
+		worst = max(worst, n)
+		if n > maxGuesses {
+			failures++
+		}
+	}
+
+	if opener == "" {
+		opener = "(strategy's choice)"
+	}
+	_, _ = fmt.Fprintf(stdout, "Strategy: %s\n", strategy)
+	_, _ = fmt.Fprintf(stdout, "Opener:   %s\n", opener)
+	_, _ = fmt.Fprintf(stdout, "Games:    %d\n", len(results))
+
+	played := len(results) - unplayed
+	if played > 0 {
+		_, _ = fmt.Fprintf(stdout, "Average:  %.3f guesses\n", float64(total)/float64(played))
+		_, _ = fmt.Fprintf(stdout, "Max:      %d guesses\n", worst)
+		_, _ = fmt.Fprintf(stdout, "Failures: %d (more than %d guesses)\n", failures, maxGuesses)
+		_, _ = fmt.Fprintf(stdout, "\nGuesses  Games\n")
+		for n := 1; n <= worst; n++ {
+			bar := strings.Repeat("#", (distribution[n]*50+played-1)/played)
+			_, _ = fmt.Fprintf(stdout, "%7d  %5d %s\n", n, distribution[n], bar)
+		}
+		_, _ = fmt.Fprintf(stdout, "\n")
+	}
+	_, _ = fmt.Fprintf(stdout, "Runtime:  %s\n", elapsed.Round(time.Millisecond))
+
+	if unplayed > 0 {
+		return fmt.Errorf("%d games could not be played", unplayed)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"wordle/solver"
+	"wordle/wordle"
+)
+
+var benchAnswers = []string{"baked", "baker", "bakes", "cakes", "crane", "fakes", "makes", "slate", "takes", "wakes"}
+
+func TestPlayAll(t *testing.T) {
+	s := &solver.Solver{Answers: benchAnswers, Guesses: benchAnswers, Strategy: wordle.Entropy}
+	results := playAll(s, benchAnswers, 3)
+	if len(results) != len(benchAnswers) {
+		t.Fatalf("playAll() returned %d results, want %d", len(results), len(benchAnswers))
+	}
+	for i, r := range results {
+		if r.err != nil || r.game.Answer != benchAnswers[i] || !r.game.Solved() {
+			t.Errorf("result %d = %+v, want %s solved", i, r, benchAnswers[i])
+		}
+	}
+	if got := playAll(s, nil, 3); len(got) != 0 {
+		t.Errorf("playAll() of no answers = %v, want none", got)
+	}
+}
+
+func TestPrintBench(t *testing.T) {
+	played := func(turns int) benchResult {
+		return benchResult{game: solver.Game{Turns: make([]solver.Turn, turns)}}
+	}
+	results := []benchResult{
+		played(1), played(2), played(2), played(7),
+		{err: errors.New("not in the answer list")},
+	}
+
+	var stdout bytes.Buffer
+	err := printBench(&stdout, "entropy", "", results, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "1 games could not be played") {
+		t.Errorf("printBench() error = %v, want 1 game not played", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"Opener:   (strategy's choice)\n",
+		"Games:    5\n",
+		"Average:  3.000 guesses\n",
+		"Max:      7 guesses\n",
+		"Failures: 1 (more than 6 guesses)\n",
+		"      1      1 " + strings.Repeat("#", 13) + "\n",
+		"      2      2 " + strings.Repeat("#", 25) + "\n",
+		"      3      0 \n",
+		"      7      1 " + strings.Repeat("#", 13) + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("printBench() output is missing %q:\n%s", want, out)
+		}
+	}
+}
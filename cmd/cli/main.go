@@ -4,60 +4,136 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"strconv"
 	"wordle/dictionary"
 	"wordle/scan"
-	"wordle/usrcmd"
 	"wordle/wordle"
 )
 
 func main() {
-	if err := Run(os.Getenv, os.Stdin, os.Stdout, os.Stderr); err != nil {
+	if err := Run(os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err)
 		os.Exit(1)
 	}
 }
 
+// Run starts the interactive helper, or the subcommand named by the first
+// argument.
 func Run(
+	args []string,
 	getenv func(string) string,
 	stdin io.Reader,
 	stdout, stderr io.Writer,
 ) error {
-	dict := getenv("WORDLE_DICTIONARY")
-	if dict == "" {
-		return fmt.Errorf("missing WORDLE_DICTIONARY environment variable")
+	if len(args) > 0 {
+		switch args[0] {
+		case "bench":
+			return runBench(args[1:], getenv, stdout, stderr)
+		case "play":
+			return runPlay(args[1:], getenv, stdin, stdout, stderr)
+		case "share":
+			return runShare(args[1:], getenv, stdin, stdout, stderr)
+		case "help", "-h", "-help", "--help":
+			printUsage(stdout)
+			return nil
+		default:
+			return fmt.Errorf("unknown command %q", args[0])
+		}
 	}
 
-	remove := getenv("WORDLE_REMOVE")
-	if remove == "" {
-		_, _ = fmt.Fprintf(stderr, "No WORDLE_REMOVE environment variable. Will not remove any words.\n")
+	strategy, err := wordle.StrategyByName(getenv("WORDLE_STRATEGY"))
+	if err != nil {
+		return err
 	}
 
-	words, err := dictionary.Create(stderr, dict, remove)
+	lists, err := loadLists(getenv, stderr)
 	if err != nil {
 		return err
 	}
-	_, _ = fmt.Fprintf(stderr, "Loaded %d words\n", len(words))
 
-	return readUserInput(stdout, stderr, stdin, words)
+	return readUserInput(stdout, stderr, stdin, lists, wordle.WithPrior(strategy, lists.Frequencies))
 }
 
-func readUserInput(stdout, stderr io.Writer, r io.Reader, words []string) error {
-	return scan.Scan(r, createLineHandler(stdout, stderr, words))
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprintf(w, `Usage:
+  wordle          read clues from stdin and print the possible words (:help lists commands)
+  wordle bench    play every answer and report how many guesses it took
+  wordle play     play a game of Wordle
+  wordle share    read NYT share text from stdin and replay it
+
+Run "wordle <command> -h" for a command's options.
+`)
 }
 
-func createLineHandler(stdout, stderr io.Writer, words []string) func(s string) error {
-	return func(s string) error {
-		missed, lettersAt, lettersNotAt, err := usrcmd.ReadUserCommand(s)
+// loadLists loads the answer and guess lists named by the environment, using
+// the built-in lists for any that aren't named, and the word-frequency table
+// and history of past answers if they are named. Only the words of
+// WORDLE_LENGTH letters (five if it isn't set) in the WORDLE_ALPHABET alphabet
+// (English if it isn't set) are kept, with accents folded if WORDLE_FOLD is
+// set.
+func loadLists(getenv func(string) string, stderr io.Writer) (dictionary.Lists, error) {
+	// WORDLE_DICTIONARY is the original name for the answer list
+	answers := getenv("WORDLE_ANSWERS")
+	if answers == "" {
+		answers = getenv("WORDLE_DICTIONARY")
+	}
+	length := wordle.WordLength
+	if s := getenv("WORDLE_LENGTH"); s != "" {
+		n, err := strconv.Atoi(s)
 		if err != nil {
-			_, _ = fmt.Fprintf(stderr, "error: %s\n", err)
-			return nil
+			return dictionary.Lists{}, fmt.Errorf("WORDLE_LENGTH: %q is not a number", s)
+		}
+		if err := wordle.CheckLength(n); err != nil {
+			return dictionary.Lists{}, fmt.Errorf("WORDLE_LENGTH: %w", err)
+		}
+		length = n
+	}
+	alphabet, err := wordle.ParseAlphabet(getenv("WORDLE_ALPHABET"))
+	if err != nil {
+		return dictionary.Lists{}, fmt.Errorf("WORDLE_ALPHABET: %w", err)
+	}
+	if s := getenv("WORDLE_FOLD"); s != "" {
+		if alphabet.Fold, err = strconv.ParseBool(s); err != nil {
+			return dictionary.Lists{}, fmt.Errorf("WORDLE_FOLD: %q is not true or false", s)
+		}
+	}
+
+	lists, err := dictionary.CreateListsFrom(stderr,
+		dictionary.FileOrEmbedded(answers, dictionary.DefaultAnswers),
+		dictionary.FileOrEmbedded(getenv("WORDLE_GUESSES"), dictionary.DefaultGuesses),
+		dictionary.FileOrEmbedded(getenv("WORDLE_REMOVE"), dictionary.DefaultRemove),
+	)
+	if err != nil {
+		return dictionary.Lists{}, err
+	}
+	if path := getenv("WORDLE_FREQUENCIES"); path != "" {
+		if lists.Frequencies, err = dictionary.LoadFrequencies(stderr, dictionary.FileSource(path)); err != nil {
+			return dictionary.Lists{}, err
+		}
+	}
+	if path := getenv("WORDLE_HISTORY"); path != "" {
+		if lists.History, err = dictionary.LoadHistory(stderr, dictionary.FileSource(path)); err != nil {
+			return dictionary.Lists{}, err
 		}
-		possibles := wordle.MakePossibles(words, missed, lettersAt, lettersNotAt)
-		printPossibles(stdout, possibles)
-		return nil
 	}
+	lists = lists.OfLength(length).InAlphabet(alphabet)
+	_, _ = fmt.Fprintf(stderr, "Loaded %d answers and %d guesses of %d letters in %s\n",
+		len(lists.Answers), len(lists.Guesses), length, alphabet)
+
+	return lists, nil
+}
+
+func readUserInput(stdout, stderr io.Writer, r io.Reader, lists dictionary.Lists, strategy wordle.Strategy) error {
+	s := newSession(stdout, lists, strategy)
+	return scan.Scan(r, func(line string) error {
+		return s.handle(line, stderr)
+	})
 }
 
+// suggestionCount is how many next-guess suggestions to print.
+const suggestionCount = 10
+
 func hasRepeatedLetters(word string) bool {
 	seen := make(map[rune]bool)
 	for _, char := range word {
@@ -80,45 +156,63 @@ func separateWordsByRepetition(words []string) (noRepeats []string, withRepeats
 	return noRepeats, withRepeats
 }
 
-func printPossibles(stdout io.Writer, possibles []string) {
-	const cols = 15
+// printPossibles prints the possible words. With a frequency table they're
+// printed most likely first in one list; otherwise alphabetically, the words
+// without repeated letters first.
+func printPossibles(stdout io.Writer, possibles []string, freqs wordle.Frequencies) {
+	if freqs != nil {
+		possibles = slices.Clone(possibles)
+		wordle.SortByLikelihood(possibles, freqs)
+		printWords(stdout, "Most Likely First", possibles)
+		return
+	}
 
 	// Separate words into two groups
 	noRepeats, withRepeats := separateWordsByRepetition(possibles)
+	printWords(stdout, "No Repeated Letters", noRepeats)
+	printWords(stdout, "Repeated Letters", withRepeats)
+}
 
-	// Print words without repeated letters
-	if len(noRepeats) > 0 {
-		_, _ = fmt.Fprintf(stdout, "No Repeated Letters (%d):\n", len(noRepeats))
-		c := 0
-		for _, word := range noRepeats {
-			_, _ = fmt.Fprintf(stdout, "%s ", word)
-			c++
-			if c == cols {
-				_, _ = fmt.Fprintf(stdout, "\n")
-				c = 0
-			}
-		}
-		if c > 0 {
+// printWords prints the words under a heading, several to a line. Nothing is
+// printed if there are no words.
+func printWords(stdout io.Writer, heading string, words []string) {
+	const cols = 15
+
+	if len(words) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(stdout, "%s (%d):\n", heading, len(words))
+	c := 0
+	for _, word := range words {
+		_, _ = fmt.Fprintf(stdout, "%s ", word)
+		c++
+		if c == cols {
 			_, _ = fmt.Fprintf(stdout, "\n")
+			c = 0
 		}
+	}
+	if c > 0 {
 		_, _ = fmt.Fprintf(stdout, "\n")
 	}
+	_, _ = fmt.Fprintf(stdout, "\n")
+}
 
-	// Print words with repeated letters
-	if len(withRepeats) > 0 {
-		_, _ = fmt.Fprintf(stdout, "Repeated Letters (%d):\n", len(withRepeats))
-		c := 0
-		for _, word := range withRepeats {
-			_, _ = fmt.Fprintf(stdout, "%s ", word)
-			c++
-			if c == cols {
-				_, _ = fmt.Fprintf(stdout, "\n")
-				c = 0
-			}
-		}
-		if c > 0 {
-			_, _ = fmt.Fprintf(stdout, "\n")
+func printSuggestions(stdout io.Writer, strategy string, suggestions []wordle.Suggestion) {
+	if len(suggestions) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(stdout, "Suggested Guesses (%s):\n", strategy)
+	marked := false
+	for _, s := range suggestions {
+		marker := ""
+		if s.Candidate {
+			marker = " *"
+			marked = true
 		}
-		_, _ = fmt.Fprintf(stdout, "\n")
+		_, _ = fmt.Fprintf(stdout, "%s %5.2f bits %8.1f left %5d worst%s\n", s.Word, s.Entropy, s.ExpectedRemaining, s.WorstCase, marker)
+	}
+	if marked {
+		_, _ = fmt.Fprintf(stdout, "(* could be the answer)\n")
 	}
+	_, _ = fmt.Fprintf(stdout, "\n")
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"wordle/wordle"
+)
+
+func TestPrintSuggestions(t *testing.T) {
+	var stdout bytes.Buffer
+	printSuggestions(&stdout, "entropy", nil)
+	if stdout.Len() != 0 {
+		t.Errorf("printSuggestions() with none printed %q, want nothing", stdout.String())
+	}
+
+	printSuggestions(&stdout, "entropy", []wordle.Suggestion{{Word: "slate"}})
+	if strings.Contains(stdout.String(), "could be the answer") {
+		t.Errorf("printSuggestions() explains a marker it didn't print:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	printSuggestions(&stdout, "entropy", []wordle.Suggestion{{Word: "slate", Candidate: true}})
+	if !strings.Contains(stdout.String(), "slate  0.00 bits      0.0 left     0 worst *\n(* could be the answer)\n") {
+		t.Errorf("printSuggestions() =\n%s", stdout.String())
+	}
+}
+
+func TestPrintPossibles(t *testing.T) {
+	words := []string{"abide", "sheet", "crane", "geese"}
+
+	var stdout bytes.Buffer
+	printPossibles(&stdout, words, nil)
+	want := "No Repeated Letters (2):\nabide crane \n\nRepeated Letters (2):\nsheet geese \n\n"
+	if stdout.String() != want {
+		t.Errorf("printPossibles() without frequencies =\n%s\nwant\n%s", stdout.String(), want)
+	}
+
+	// A common word with a repeated letter comes before rarer words without one
+	stdout.Reset()
+	printPossibles(&stdout, words, wordle.Frequencies{"sheet": 100, "crane": 10, "abide": 1})
+	want = "Most Likely First (4):\nsheet crane abide geese \n\n"
+	if stdout.String() != want {
+		t.Errorf("printPossibles() with frequencies =\n%s\nwant\n%s", stdout.String(), want)
+	}
+	if !slices.Equal(words, []string{"abide", "sheet", "crane", "geese"}) {
+		t.Errorf("printPossibles() reordered its argument to %v", words)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+	"wordle/scan"
+	"wordle/wordle"
+)
+
+// firstWordle is the date of the first NYT Wordle, puzzle number 0.
+var firstWordle = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+// keyboardRows is the layout the keyboard summary is printed in.
+var keyboardRows = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// keyboard returns the rows of keys for the alphabet: the usual layout, with
+// the alphabet's other letters on a row of their own.
+func keyboard(a wordle.Alphabet) []string {
+	rows := slices.Clone(keyboardRows)
+	var extra []rune
+	for _, r := range a.Letters {
+		if !strings.ContainsRune(strings.Join(keyboardRows, ""), r) {
+			extra = append(extra, r)
+		}
+	}
+	if len(extra) > 0 {
+		rows = append(rows, string(extra))
+	}
+	return rows
+}
+
+// errGameOver stops reading guesses once the game has ended.
+var errGameOver = errors.New("game over")
+
+// game is a game of Wordle being played on the command line.
+type game struct {
+	answer  string
+	number  int // day number, or -1 if the answer wasn't picked by date
+	guesses []string
+	allowed map[string]bool
+	rows    []wordle.Pattern
+	keys    map[rune]wordle.Feedback
+	keyRows []string
+	color   bool
+}
+
+// runPlay lets the user play Wordle against a secret answer.
+func runPlay(args []string, getenv func(string) string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("play", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	seed := flags.Uint64("seed", 0, "pick the answer with this random seed (0 picks at random)")
+	date := flags.String("date", "", `pick the answer for a date, as YYYY-MM-DD or "today"; the share grid is numbered by the day, not the NYT puzzle`)
+	plain := flags.Bool("plain", false, "print tiles as letters instead of ANSI colors")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	lists, err := loadLists(getenv, stderr)
+	if err != nil {
+		return err
+	}
+	if len(lists.Answers) == 0 {
+		return fmt.Errorf("the answer list is empty")
+	}
+
+	g := &game{
+		number:  -1,
+		allowed: make(map[string]bool, len(lists.Guesses)),
+		keys:    make(map[rune]wordle.Feedback),
+		keyRows: keyboard(lists.Alphabet),
+		color:   !*plain,
+	}
+	for _, word := range lists.Guesses {
+		g.allowed[word] = true
+	}
+
+	switch {
+	case *date != "":
+		day := time.Now().UTC().Truncate(24 * time.Hour)
+		if *date != "today" {
+			if day, err = time.Parse(time.DateOnly, *date); err != nil {
+				return fmt.Errorf("invalid date %q: %w", *date, err)
+			}
+		}
+		// The number counts days like the NYT puzzle numbers, but the answer
+		// is picked from our list, so it isn't that NYT puzzle's answer
+		g.number = int(day.Sub(firstWordle).Hours() / 24)
+		if g.number < 0 {
+			return fmt.Errorf("there was no Wordle on %s", day.Format(time.DateOnly))
+		}
+		g.answer = lists.Answers[g.number%len(lists.Answers)]
+	case *seed != 0:
+		r := rand.New(rand.NewPCG(*seed, *seed))
+		g.answer = lists.Answers[r.IntN(len(lists.Answers))]
+	default:
+		g.answer = lists.Answers[rand.IntN(len(lists.Answers))]
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Guess the %d letter word in %d tries.\n", wordle.Len(g.answer), maxGuesses)
+	if !g.color {
+		_, _ = fmt.Fprintf(stdout, "[A] is in the right spot, (A) is in the word but the wrong spot, a is not in the word.\n")
+	}
+	err = scan.Scan(stdin, func(line string) error {
+		return g.guess(stdout, lists.Alphabet.NormalizeText(strings.TrimSpace(line)))
+	})
+	if errors.Is(err, errGameOver) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "The word was %s.\n", strings.ToUpper(g.answer))
+	return nil
+}
+
+// guess plays one guess and prints the board. It returns errGameOver when the
+// game has been won or lost.
+func (g *game) guess(stdout io.Writer, word string) error {
+	if word == "" {
+		return nil
+	}
+	if n := wordle.Len(g.answer); wordle.Len(word) != n {
+		_, _ = fmt.Fprintf(stdout, "%q is not %d letters.\n", word, n)
+		return nil
+	}
+	if !g.allowed[word] {
+		_, _ = fmt.Fprintf(stdout, "%q is not in the word list.\n", word)
+		return nil
+	}
+
+	pattern := wordle.Score(word, g.answer)
+	g.guesses = append(g.guesses, word)
+	g.rows = append(g.rows, pattern)
+	for i, letter := range []rune(word) {
+		if f, ok := g.keys[letter]; !ok || pattern.At(i) > f {
+			g.keys[letter] = pattern.At(i)
+		}
+	}
+
+	g.printBoard(stdout)
+
+	switch {
+	case pattern.Solved():
+		_, _ = fmt.Fprintf(stdout, "Solved in %d!\n\n%s", len(g.rows), g.share())
+		return errGameOver
+	case len(g.rows) == maxGuesses:
+		_, _ = fmt.Fprintf(stdout, "Out of guesses. The word was %s.\n\n%s", strings.ToUpper(g.answer), g.share())
+		return errGameOver
+	}
+	return nil
+}
+
+func (g *game) printBoard(stdout io.Writer) {
+	_, _ = fmt.Fprintf(stdout, "\n")
+	for i, word := range g.guesses {
+		var row strings.Builder
+		for j, letter := range []rune(word) {
+			row.WriteString(g.tile(letter, g.rows[i].At(j)))
+		}
+		_, _ = fmt.Fprintf(stdout, "%s\n", row.String())
+	}
+	_, _ = fmt.Fprintf(stdout, "\n")
+
+	for i, keys := range g.keyRows {
+		var row strings.Builder
+		row.WriteString(strings.Repeat(" ", i))
+		for _, key := range keys {
+			f, played := g.keys[key]
+			if !played {
+				row.WriteString(" " + strings.ToUpper(string(key)) + " ")
+				continue
+			}
+			row.WriteString(g.tile(key, f))
+		}
+		_, _ = fmt.Fprintf(stdout, "%s\n", row.String())
+	}
+	_, _ = fmt.Fprintf(stdout, "\n")
+}
+
+// tile renders one letter colored by its feedback.
+func (g *game) tile(letter rune, f wordle.Feedback) string {
+	upper := strings.ToUpper(string(letter))
+	if !g.color {
+		switch f {
+		case wordle.Green:
+			return "[" + upper + "]"
+		case wordle.Yellow:
+			return "(" + upper + ")"
+		default:
+			return " " + strings.ToLower(upper) + " "
+		}
+	}
+	switch f {
+	case wordle.Green:
+		return "\x1b[1;97;42m " + upper + " \x1b[0m"
+	case wordle.Yellow:
+		return "\x1b[1;97;43m " + upper + " \x1b[0m"
+	default:
+		return "\x1b[1;97;100m " + upper + " \x1b[0m"
+	}
+}
+
+// share returns the emoji grid in the format the NYT share button uses. A game
+// picked by date is numbered by the day, which only identifies the date; its
+// answer isn't the NYT one.
+func (g *game) share() string {
+	score := "X"
+	if len(g.rows) > 0 && g.rows[len(g.rows)-1].Solved() {
+		score = fmt.Sprint(len(g.rows))
+	}
+	var sb strings.Builder
+	if g.number >= 0 {
+		_, _ = fmt.Fprintf(&sb, "Wordle %s %s/%d\n\n", thousands(g.number), score, maxGuesses)
+	} else {
+		_, _ = fmt.Fprintf(&sb, "Wordle %s/%d\n\n", score, maxGuesses)
+	}
+	for _, row := range g.rows {
+		sb.WriteString(row.Emoji())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// thousands formats n with commas between groups of three digits.
+func thousands(n int) string {
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
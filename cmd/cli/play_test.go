@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"wordle/wordle"
+)
+
+// newTestGame returns a game against answer that prints plain tiles.
+func newTestGame(answer string, allowed ...string) *game {
+	g := &game{
+		answer:  answer,
+		number:  -1,
+		allowed: map[string]bool{answer: true},
+		keys:    make(map[rune]wordle.Feedback),
+		keyRows: keyboard(wordle.English),
+	}
+	for _, word := range allowed {
+		g.allowed[word] = true
+	}
+	return g
+}
+
+func TestGameKeys(t *testing.T) {
+	g := newTestGame("guide", "crane", "split")
+	var stdout bytes.Buffer
+	for _, word := range []string{"crane", "split"} {
+		if err := g.guess(&stdout, word); err != nil {
+			t.Fatalf("guess(%s) error = %v", word, err)
+		}
+	}
+	want := map[rune]wordle.Feedback{
+		'c': wordle.Gray, 'r': wordle.Gray, 'a': wordle.Gray, 'n': wordle.Gray, 'e': wordle.Green,
+		's': wordle.Gray, 'p': wordle.Gray, 'l': wordle.Gray, 'i': wordle.Yellow, 't': wordle.Gray,
+	}
+	for letter, f := range want {
+		if g.keys[letter] != f {
+			t.Errorf("key %c = %v, want %v", letter, g.keys[letter], f)
+		}
+	}
+	if len(g.keys) != len(want) {
+		t.Errorf("%d keys played, want %d", len(g.keys), len(want))
+	}
+	if !strings.Contains(stdout.String(), " Q  W [E] r  t ") {
+		t.Errorf("keyboard doesn't show the played keys:\n%s", stdout.String())
+	}
+
+	// A letter found in place is green on the keyboard from then on
+	stdout.Reset()
+	if err := g.guess(&stdout, "guide"); err != errGameOver {
+		t.Fatalf("guess(guide) error = %v, want the game to be over", err)
+	}
+	if g.keys['i'] != wordle.Green {
+		t.Errorf("key i = %v after it was green, want green", g.keys['i'])
+	}
+	if !strings.Contains(stdout.String(), "Solved in 3!\n\nWordle 3/6\n\n⬛⬛⬛⬛🟩\n⬛⬛⬛🟨⬛\n🟩🟩🟩🟩🟩\n") {
+		t.Errorf("game over output:\n%s", stdout.String())
+	}
+}
+
+func TestGameRejects(t *testing.T) {
+	g := newTestGame("guide")
+	var stdout bytes.Buffer
+	for word, want := range map[string]string{
+		"crane": `"crane" is not in the word list.`,
+		"cat":   `"cat" is not 5 letters.`,
+	} {
+		stdout.Reset()
+		if err := g.guess(&stdout, word); err != nil {
+			t.Fatalf("guess(%s) error = %v", word, err)
+		}
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("guess(%s) printed %q, want %q", word, stdout.String(), want)
+		}
+	}
+	if len(g.rows) != 0 {
+		t.Errorf("rejected guesses were played: %v", g.rows)
+	}
+}
+
+func TestGameLost(t *testing.T) {
+	g := newTestGame("guide", "crane")
+	g.number = 1234
+	var stdout bytes.Buffer
+	for i := 0; i < maxGuesses; i++ {
+		if err := g.guess(&stdout, "crane"); err != nil && i < maxGuesses-1 {
+			t.Fatalf("guess %d error = %v", i+1, err)
+		}
+	}
+	want := "Out of guesses. The word was GUIDE.\n\nWordle 1,234 X/6\n\n" + strings.Repeat("⬛⬛⬛⬛🟩\n", maxGuesses)
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("output is missing %q:\n%s", want, stdout.String())
+	}
+}
+
+func TestPlayDate(t *testing.T) {
+	dir := t.TempDir()
+	answers := filepath.Join(dir, "answers")
+	if err := os.WriteFile(answers, []byte("crane\nguide\nslate\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	getenv := func(key string) string {
+		if key == "WORDLE_ANSWERS" {
+			return answers
+		}
+		return ""
+	}
+
+	// The answers are numbered by the day, counting from the first NYT
+	// Wordle, and the answer is that day's word in the list
+	tests := []struct {
+		date, guesses, share string
+	}{
+		{"2021-06-19", "crane\n", "Wordle 0 1/6\n"},
+		{"2021-06-20", "crane\nguide\n", "Wordle 1 2/6\n"},
+		{"2024-06-19", "guide\n", "Wordle 1,096 1/6\n"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		err := runPlay([]string{"-plain", "-date", tt.date}, getenv, strings.NewReader(tt.guesses), &stdout, &stderr)
+		if err != nil {
+			t.Fatalf("%s: runPlay() error = %v", tt.date, err)
+		}
+		if !strings.Contains(stdout.String(), tt.share) {
+			t.Errorf("%s: share grid doesn't start %q:\n%s", tt.date, tt.share, stdout.String())
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := runPlay([]string{"-date", "2021-06-18"}, getenv, strings.NewReader(""), &stdout, &stderr); err == nil {
+		t.Errorf("runPlay() before the first Wordle error = nil, want error")
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	if got := keyboard(wordle.English); len(got) != len(keyboardRows) {
+		t.Errorf("keyboard(English) = %q, want the usual rows", got)
+	}
+	if got := keyboard(wordle.Spanish); len(got) != len(keyboardRows)+1 || got[len(got)-1] != "ñ" {
+		t.Errorf("keyboard(Spanish) = %q, want an extra row with ñ", got)
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"wordle/dictionary"
+	"wordle/scan"
+	"wordle/usrcmd"
+	"wordle/wordle"
+)
+
+// session is an interactive solving session. Every scored guess or line of
+// constraints the user enters is added to what is already known, and the
+// candidates are narrowed from the ones left after the previous step.
+type session struct {
+	lists    dictionary.Lists
+	strategy wordle.Strategy
+	stdout   io.Writer
+	steps    []step
+
+	// past is the words that were already the answer, which are left out
+	// while excludePast is set
+	past        map[string]bool
+	excludePast bool
+}
+
+// step is one accepted line of input and what was known after it.
+type step struct {
+	input       string
+	constraints wordle.Constraints
+	possibles   []string
+}
+
+// newSession starts a session with nothing entered. Past answers are left out
+// if the lists have a history.
+func newSession(stdout io.Writer, lists dictionary.Lists, strategy wordle.Strategy) *session {
+	past := lists.History.UsedBefore(time.Now())
+	return &session{lists: lists, strategy: strategy, stdout: stdout, past: past, excludePast: len(past) > 0}
+}
+
+// fresh returns a session with the same settings and nothing entered.
+func (s *session) fresh() *session {
+	return &session{lists: s.lists, strategy: s.strategy, stdout: s.stdout, past: s.past, excludePast: s.excludePast}
+}
+
+// sessionHelp describes the commands a session understands.
+const sessionHelp = `Enter a scored guess ("crane xygxx") or a line of constraints ("cne . -r a . . e=1").
+Commands:
+  :undo          forget the last guess or constraints
+  :reset         start over
+  :history       list what has been entered
+  :show          print the possible words
+  :suggest       print the suggested next guesses
+  :past          leave out or bring back past answers (needs WORDLE_HISTORY)
+  :save <file>   write what has been entered to a file
+  :load <file>   start over from a file written by :save
+  :help          print this help
+`
+
+// handle processes one line of input. Mistakes are reported on stderr and
+// leave the session unchanged.
+func (s *session) handle(line string, stderr io.Writer) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	var err error
+	if strings.HasPrefix(line, ":") {
+		err = s.command(line)
+	} else if err = s.add(line); err == nil {
+		s.print()
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: %s\n", err)
+	}
+	return nil
+}
+
+func (s *session) command(line string) error {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case ":undo":
+		if len(s.steps) == 0 {
+			return fmt.Errorf("nothing to undo")
+		}
+		s.steps = s.steps[:len(s.steps)-1]
+		if len(s.steps) == 0 {
+			s.printStart()
+			return nil
+		}
+		s.print()
+	case ":reset":
+		s.steps = nil
+		s.printStart()
+	case ":history":
+		s.printHistory()
+	case ":show":
+		printPossibles(s.stdout, s.possibles(), s.lists.Frequencies)
+	case ":suggest":
+		s.printSuggestions()
+	case ":past":
+		return s.togglePast()
+	case ":save":
+		if arg == "" {
+			return fmt.Errorf("usage: :save <file>")
+		}
+		return s.save(arg)
+	case ":load":
+		if arg == "" {
+			return fmt.Errorf("usage: :load <file>")
+		}
+		if err := s.load(arg); err != nil {
+			return err
+		}
+		s.printHistory()
+		s.print()
+	case ":help":
+		_, _ = fmt.Fprint(s.stdout, sessionHelp)
+	default:
+		return fmt.Errorf("unknown command %q (try :help)", name)
+	}
+	return nil
+}
+
+// add reads a scored guess or a line of constraints, merges it with what is
+// already known and narrows the candidates. Letters are normalized to the
+// lists' alphabet, so with accents folded "está" is read as "esta".
+func (s *session) add(line string) error {
+	var constraints wordle.Constraints
+	var err error
+	line = s.lists.Alphabet.NormalizeText(line)
+	if usrcmd.IsGuess(line) {
+		var guess wordle.Guess
+		if guess, err = usrcmd.ReadGuess(line); err == nil {
+			constraints = wordle.NewConstraints(guess)
+		}
+	} else {
+		constraints, err = usrcmd.ReadUserCommand(line)
+	}
+	if err != nil {
+		return err
+	}
+	if err := constraints.CheckLen(s.lists.Length); err != nil {
+		return err
+	}
+
+	constraints = s.constraints().Merge(constraints)
+	if err := constraints.Validate(); err != nil {
+		return err
+	}
+	s.steps = append(s.steps, step{
+		input:       line,
+		constraints: constraints,
+		possibles:   wordle.MakePossibles(s.possibles(), constraints),
+	})
+	return nil
+}
+
+// constraints returns everything known so far.
+func (s *session) constraints() wordle.Constraints {
+	if len(s.steps) == 0 {
+		return wordle.Constraints{Length: s.lists.Length}
+	}
+	return s.steps[len(s.steps)-1].constraints
+}
+
+// possibles returns the words that are still possible.
+func (s *session) possibles() []string {
+	if len(s.steps) == 0 {
+		return s.answers()
+	}
+	return s.steps[len(s.steps)-1].possibles
+}
+
+// answers returns the words that could be the answer before anything is
+// entered.
+func (s *session) answers() []string {
+	if !s.excludePast {
+		return s.lists.Answers
+	}
+	var answers []string
+	for _, w := range s.lists.Answers {
+		if !s.past[w] {
+			answers = append(answers, w)
+		}
+	}
+	return answers
+}
+
+// togglePast switches between leaving out past answers and including them,
+// and narrows the candidates again from what has been entered.
+func (s *session) togglePast() error {
+	if len(s.past) == 0 {
+		return fmt.Errorf("no past answers are loaded (set WORDLE_HISTORY)")
+	}
+	toggled := s.fresh()
+	toggled.excludePast = !s.excludePast
+	for _, st := range s.steps {
+		if err := toggled.add(st.input); err != nil {
+			return err
+		}
+	}
+	*s = *toggled
+
+	if s.excludePast {
+		_, _ = fmt.Fprintf(s.stdout, "Leaving out %d past answers.\n", len(s.past))
+	} else {
+		_, _ = fmt.Fprintf(s.stdout, "Including past answers.\n")
+	}
+	if len(s.steps) == 0 {
+		s.printStart()
+		return nil
+	}
+	s.print()
+	return nil
+}
+
+// save writes every accepted line to path, one per line, so the file can be
+// loaded again or piped into the helper.
+func (s *session) save(path string) error {
+	var sb strings.Builder
+	for _, st := range s.steps {
+		sb.WriteString(st.input)
+		sb.WriteString("\n")
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(s.stdout, "Saved %d lines to %s\n\n", len(s.steps), path)
+	return nil
+}
+
+// load replaces the session with the lines in path. The session is left as
+// it was if any line is invalid.
+func (s *session) load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	loaded := s.fresh()
+	n := 0
+	err = scan.Scan(f, func(line string) error {
+		n++
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			return nil
+		}
+		if err := loaded.add(line); err != nil {
+			return fmt.Errorf("%s line %d: %w", path, n, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.steps = loaded.steps
+	return nil
+}
+
+// print prints the possible words and, once there is a clue, the suggested
+// next guesses. Before that ranking would take seconds; :suggest still does it.
+func (s *session) print() {
+	printPossibles(s.stdout, s.possibles(), s.lists.Frequencies)
+	if s.constraints().HasClues() {
+		s.printSuggestions()
+	}
+}
+
+// printStart reports that nothing is known yet. Suggestions are left for
+// :suggest since ranking against every answer is slow.
+func (s *session) printStart() {
+	_, _ = fmt.Fprintf(s.stdout, "Starting over with %d possible words.\n\n", len(s.answers()))
+}
+
+func (s *session) printSuggestions() {
+	printSuggestions(s.stdout, s.strategy.Name(), s.strategy.Rank(s.lists.Guesses, s.possibles(), suggestionCount))
+}
+
+func (s *session) printHistory() {
+	if len(s.steps) == 0 {
+		_, _ = fmt.Fprintf(s.stdout, "Nothing entered yet.\n\n")
+		return
+	}
+	for i, st := range s.steps {
+		_, _ = fmt.Fprintf(s.stdout, "%2d. %-24s %5d left\n", i+1, st.input, len(st.possibles))
+	}
+	_, _ = fmt.Fprintf(s.stdout, "Known: %s\n\n", s.constraints())
+}
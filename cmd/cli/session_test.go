@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"wordle/dictionary"
+	"wordle/wordle"
+)
+
+var sessionWords = []string{"abide", "aside", "baked", "crane", "guide", "slide", "snide", "split"}
+
+func TestSession(t *testing.T) {
+	lists := dictionary.Lists{Answers: sessionWords, Guesses: sessionWords, Length: 5}
+	dir := t.TempDir()
+	tests := []struct {
+		name      string
+		lines     []string
+		steps     int
+		possibles []string
+		stdout    string // printed somewhere in the output
+		stderr    string // the error printed, if any
+	}{
+		{
+			name:      "merge",
+			lines:     []string{"crane xxxxg", "g . . . . ."},
+			steps:     2,
+			possibles: []string{"slide"},
+		},
+		{
+			name:      "undo after a merge",
+			lines:     []string{"crane xxxxg", "g . . . . .", ":undo"},
+			steps:     1,
+			possibles: []string{"guide", "slide"},
+		},
+		{
+			name:      "undo everything",
+			lines:     []string{"crane xxxxg", ":undo", ":undo"},
+			possibles: sessionWords,
+			stderr:    "nothing to undo",
+		},
+		{
+			name:      "reset",
+			lines:     []string{"crane xxxxg", "g . . . . .", ":reset"},
+			possibles: sessionWords,
+			stdout:    "Starting over with 8 possible words.",
+		},
+		{
+			name:      "bad line",
+			lines:     []string{"crane xxxxg", "zz . ."},
+			steps:     1,
+			possibles: []string{"guide", "slide"},
+			stderr:    "error: ",
+		},
+		{
+			name:      "contradiction",
+			lines:     []string{"crane xxxxg", "x . . . . a"},
+			steps:     1,
+			possibles: []string{"guide", "slide"},
+			stderr:    "position 5 cannot be both",
+		},
+		{
+			name:      "wrong length",
+			lines:     []string{"crane xxxxg", "guides xxxxxx"},
+			steps:     1,
+			possibles: []string{"guide", "slide"},
+			stderr:    "clues are for 6 letter words, not 5",
+		},
+		{
+			name:      "save and load",
+			lines:     []string{"crane xxxxg", "g . . . . .", ":save " + dir + "/game", ":reset", ":load " + dir + "/game"},
+			steps:     2,
+			possibles: []string{"slide"},
+			stdout:    "Saved 2 lines to " + dir + "/game",
+		},
+		{
+			name:      "load a missing file",
+			lines:     []string{"crane xxxxg", ":load " + dir + "/missing"},
+			steps:     1,
+			possibles: []string{"guide", "slide"},
+			stderr:    "no such file",
+		},
+		{
+			name:      "unknown command",
+			lines:     []string{":frobnicate"},
+			possibles: sessionWords,
+			stderr:    `unknown command ":frobnicate"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			s := newSession(&stdout, lists, wordle.Entropy)
+			for _, line := range tt.lines {
+				if err := s.handle(line, &stderr); err != nil {
+					t.Fatalf("handle(%q) error = %v", line, err)
+				}
+			}
+			if len(s.steps) != tt.steps {
+				t.Errorf("%d steps, want %d", len(s.steps), tt.steps)
+			}
+			if got := s.possibles(); !slices.Equal(got, tt.possibles) {
+				t.Errorf("possibles = %v, want %v", got, tt.possibles)
+			}
+			if !strings.Contains(stdout.String(), tt.stdout) {
+				t.Errorf("stdout is missing %q:\n%s", tt.stdout, stdout.String())
+			}
+			if tt.stderr == "" && stderr.Len() > 0 {
+				t.Errorf("stderr = %q, want nothing", stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.stderr)
+			}
+		})
+	}
+}
+
+func TestSessionPast(t *testing.T) {
+	lists := dictionary.Lists{
+		Answers: sessionWords,
+		Guesses: sessionWords,
+		Length:  5,
+		History: dictionary.History{{Date: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), Word: "slide"}},
+	}
+	var stdout, stderr bytes.Buffer
+	s := newSession(&stdout, lists, wordle.Entropy)
+	_ = s.handle("crane xxxxg", &stderr)
+	if got, want := s.possibles(), []string{"guide"}; !slices.Equal(got, want) {
+		t.Errorf("possibles = %v, want %v without the past answer", got, want)
+	}
+	_ = s.handle(":past", &stderr)
+	if got, want := s.possibles(), []string{"guide", "slide"}; !slices.Equal(got, want) || len(s.steps) != 1 {
+		t.Errorf("after :past possibles = %v in %d steps, want %v in 1", got, len(s.steps), want)
+	}
+	if stderr.Len() > 0 {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"wordle/share"
+)
+
+// runShare reads NYT share text from stdin and shows how many answers were
+// still possible after each row.
+func runShare(args []string, getenv func(string) string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("share", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	guessList := flags.String("guesses", "", "the words guessed, in order, separated by commas or spaces")
+	answer := flags.String("answer", "", "the answer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	text, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	result, err := share.Parse(string(text))
+	if err != nil {
+		return err
+	}
+	guesses := share.SplitGuesses(*guessList)
+	*answer = strings.ToLower(strings.TrimSpace(*answer))
+
+	score := "X"
+	if result.Solved() {
+		score = fmt.Sprint(result.Score)
+	}
+	hard := ""
+	if result.HardMode {
+		hard = " (hard mode)"
+	}
+	_, _ = fmt.Fprintf(stdout, "Wordle %s %s/%d%s\n\n", thousands(result.Number), score, result.MaxGuesses, hard)
+
+	if len(guesses) == 0 && *answer == "" {
+		for _, row := range result.Rows {
+			_, _ = fmt.Fprintf(stdout, "%s\n", row.Emoji())
+		}
+		_, _ = fmt.Fprintf(stdout, "\nGive -guesses or -answer to see how many words were left after each row.\n")
+		return nil
+	}
+
+	lists, err := loadLists(getenv, stderr)
+	if err != nil {
+		return err
+	}
+
+	if len(guesses) > 0 {
+		steps, err := result.Replay(guesses, *answer, lists.Answers)
+		if err != nil {
+			return err
+		}
+		for _, step := range steps {
+			_, _ = fmt.Fprintf(stdout, "%s %s %6d left\n", step.Pattern.Emoji(), step.Guess, step.Remaining)
+		}
+		return nil
+	}
+
+	steps, err := result.ReplayAnswer(*answer, lists.Guesses, lists.Answers)
+	if err != nil {
+		return err
+	}
+	for _, step := range steps {
+		examples := step.Guesses
+		if len(examples) > 5 {
+			examples = examples[:5]
+		}
+		_, _ = fmt.Fprintf(stdout, "%s at most %6d left, %5d possible guesses (%s...)\n",
+			step.Pattern.Emoji(), step.Remaining, len(step.Guesses), strings.Join(examples, " "))
+	}
+	return nil
+}
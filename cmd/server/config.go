@@ -0,0 +1,170 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+	"wordle/wordle"
+)
+
+// minAdminTokenLength is the shortest admin token accepted, to rule out
+// tokens that are easy to guess.
+const minAdminTokenLength = 16
+
+// Config is everything the server needs to start. Each setting comes from a
+// flag, which defaults to the environment variable named in its usage. Empty
+// word list paths and StaticDir select the files built into the binary.
+type Config struct {
+	Host      string
+	Port      string
+	Answers   string
+	Guesses   string
+	Remove    string
+	StaticDir string
+
+	// Frequencies is a word-frequency table used to rank the answers, or
+	// empty to treat every answer as equally likely. There's no built-in one.
+	Frequencies string
+	// History is a CSV file of the past official answers, which the form
+	// offers to leave out, or empty for none.
+	History string
+
+	// Length is how many letters the words have, as in Lingo and other
+	// variants of Wordle
+	Length int
+	// Alphabet is the alphabet the words are in, a built-in one or the
+	// letters themselves. Fold plays accented letters that aren't in it as
+	// the plain ones, so "á" is played as "a".
+	Alphabet wordle.Alphabet
+	Fold     bool
+
+	// AdminToken enables the /admin endpoints for requests that carry it.
+	// It is only read from the environment so it doesn't show up in ps.
+	AdminToken string
+
+	// WatchInterval is how often to check the word files for changes, or 0
+	// to only reload them on SIGHUP
+	WatchInterval time.Duration
+
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// loadConfig reads the configuration from the environment and then the flags
+// in args, and validates it.
+func loadConfig(args []string, getenv func(string) string, stderr io.Writer) (Config, error) {
+	// Use PORT from Heroku, fallback to WORDLE_PORT or 8080
+	port := getenv("PORT")
+	if port == "" {
+		port = getenv("WORDLE_PORT")
+	}
+	// WORDLE_DICTIONARY is the original name for the answer list
+	answers := getenv("WORDLE_ANSWERS")
+	if answers == "" {
+		answers = getenv("WORDLE_DICTIONARY")
+	}
+
+	length := wordle.WordLength
+	if v := getenv("WORDLE_LENGTH"); v != "" {
+		var err error
+		if length, err = strconv.Atoi(v); err != nil {
+			return Config{}, fmt.Errorf("invalid WORDLE_LENGTH: %q is not a number", v)
+		}
+	}
+
+	fold := false
+	if v := getenv("WORDLE_FOLD"); v != "" {
+		var err error
+		if fold, err = strconv.ParseBool(v); err != nil {
+			return Config{}, fmt.Errorf("invalid WORDLE_FOLD: %q is not true or false", v)
+		}
+	}
+
+	var watch time.Duration
+	if v := getenv("WORDLE_WATCH_INTERVAL"); v != "" {
+		var err error
+		if watch, err = time.ParseDuration(v); err != nil {
+			return Config{}, fmt.Errorf("invalid WORDLE_WATCH_INTERVAL: %w", err)
+		}
+	}
+
+	cfg := Config{AdminToken: getenv("WORDLE_ADMIN_TOKEN")}
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&cfg.Host, "host", withDefault(getenv("WORDLE_HOST"), "0.0.0.0"), "address to listen on ($WORDLE_HOST)")
+	flags.StringVar(&cfg.Port, "port", withDefault(port, "8080"), "port to listen on, 0 picks a free one ($PORT or $WORDLE_PORT)")
+	flags.StringVar(&cfg.Answers, "answers", answers, "file of words that could be the answer, instead of the built-in list ($WORDLE_ANSWERS or $WORDLE_DICTIONARY)")
+	flags.StringVar(&cfg.Guesses, "guesses", getenv("WORDLE_GUESSES"), "file of words that may be guessed, instead of the built-in list ($WORDLE_GUESSES)")
+	flags.StringVar(&cfg.Remove, "remove", getenv("WORDLE_REMOVE"), "file of words to leave out, instead of the built-in list ($WORDLE_REMOVE)")
+	flags.StringVar(&cfg.Frequencies, "frequencies", getenv("WORDLE_FREQUENCIES"), "file of word frequencies to rank the answers by ($WORDLE_FREQUENCIES)")
+	flags.StringVar(&cfg.History, "history", getenv("WORDLE_HISTORY"), "CSV file of past answers (date,number,word) to offer to leave out ($WORDLE_HISTORY)")
+	flags.IntVar(&cfg.Length, "length", length, "how many letters the words have ($WORDLE_LENGTH)")
+	alphabet := flags.String("alphabet", getenv("WORDLE_ALPHABET"), "alphabet the words are in: english, spanish, german, portuguese or the letters ($WORDLE_ALPHABET)")
+	flags.BoolVar(&cfg.Fold, "fold", fold, "play accented letters that aren't in the alphabet as plain ones ($WORDLE_FOLD)")
+	flags.StringVar(&cfg.StaticDir, "static", "", "directory of static files to serve instead of the built-in ones")
+	flags.DurationVar(&cfg.WatchInterval, "watch", watch, "how often to check the word files for changes, 0 to only reload on SIGHUP ($WORDLE_WATCH_INTERVAL)")
+	flags.DurationVar(&cfg.ReadTimeout, "read-timeout", 10*time.Second, "longest time to read a request")
+	flags.DurationVar(&cfg.WriteTimeout, "write-timeout", 30*time.Second, "longest time to write a response")
+	flags.DurationVar(&cfg.IdleTimeout, "idle-timeout", 2*time.Minute, "longest time to keep an idle connection open")
+	flags.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", 15*time.Second, "longest time to wait for requests to finish when stopping")
+	if err := flags.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if flags.NArg() > 0 {
+		return Config{}, fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	var err error
+	if cfg.Alphabet, err = wordle.ParseAlphabet(*alphabet); err != nil {
+		return Config{}, err
+	}
+	cfg.Alphabet.Fold = cfg.Fold
+
+	return cfg, cfg.Validate()
+}
+
+// Validate checks that the configuration can be used to start the server.
+func (c Config) Validate() error {
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 0 || port > 65535 {
+		return fmt.Errorf("invalid port %q", c.Port)
+	}
+	if c.AdminToken != "" && len(c.AdminToken) < minAdminTokenLength {
+		return fmt.Errorf("WORDLE_ADMIN_TOKEN must be at least %d characters", minAdminTokenLength)
+	}
+	if err := wordle.CheckLength(c.Length); err != nil {
+		return err
+	}
+	if c.WatchInterval < 0 {
+		return fmt.Errorf("watch interval must not be negative, got %s", c.WatchInterval)
+	}
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"read timeout", c.ReadTimeout},
+		{"write timeout", c.WriteTimeout},
+		{"idle timeout", c.IdleTimeout},
+		{"shutdown timeout", c.ShutdownTimeout},
+	} {
+		if d.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", d.name, d.value)
+		}
+	}
+	return nil
+}
+
+// Addr is the address to listen on.
+func (c Config) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+func withDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -1,82 +1,183 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"wordle/dictionary"
 	"wordle/handlers"
+	"wordle/web"
 )
 
 func main() {
-	if err := run(); err != nil {
-		log.Fatal(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Stop catching signals once the first one arrives, so a second one kills
+	// a server that is slow to shut down
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
 	}
 }
 
-func run() error {
-	// Load configuration from environment
-	dict := os.Getenv("WORDLE_DICTIONARY")
-	if dict == "" {
-		return fmt.Errorf("missing WORDLE_DICTIONARY environment variable")
+// run starts the server and serves until ctx is cancelled, then waits for
+// requests in flight to finish.
+func run(
+	ctx context.Context,
+	args []string,
+	getenv func(string) string,
+	stdout, stderr io.Writer,
+) error {
+	cfg, err := loadConfig(args, getenv, stderr)
+	if err != nil {
+		return err
 	}
 
-	remove := os.Getenv("WORDLE_REMOVE")
-	if remove == "" {
-		_, _ = fmt.Fprintf(os.Stderr, "No WORDLE_REMOVE environment variable. Will not remove any words.\n")
+	// Initialize dictionary, using the built-in lists for any file not given
+	answers := dictionary.FileOrEmbedded(cfg.Answers, dictionary.DefaultAnswers)
+	_, _ = fmt.Fprintf(stderr, "Loading dictionary from %s\n", answers)
+	wordList, err := dictionary.NewWordListFrom(stderr,
+		answers,
+		dictionary.FileOrEmbedded(cfg.Guesses, dictionary.DefaultGuesses),
+		dictionary.FileOrEmbedded(cfg.Remove, dictionary.DefaultRemove),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load dictionary: %w", err)
 	}
-
-	// Use PORT from Heroku, fallback to WORDLE_PORT or 8080
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = os.Getenv("WORDLE_PORT")
-		if port == "" {
-			port = "8080"
+	if err := wordList.SetLength(cfg.Length); err != nil {
+		return err
+	}
+	wordList.SetAlphabet(cfg.Alphabet)
+	if cfg.Frequencies != "" {
+		if err := wordList.SetFrequencies(dictionary.FileSource(cfg.Frequencies)); err != nil {
+			return fmt.Errorf("failed to load word frequencies: %w", err)
 		}
 	}
+	if cfg.History != "" {
+		if err := wordList.SetHistory(dictionary.FileSource(cfg.History)); err != nil {
+			return fmt.Errorf("failed to load past answers: %w", err)
+		}
+	}
+
+	// Set up logging
+	logger := slog.New(slog.NewTextHandler(stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}))
+
+	go reloadOnHangup(ctx, logger, wordList)
+	if cfg.WatchInterval > 0 {
+		logger.Info("Watching dictionary files for changes", "interval", cfg.WatchInterval)
+		go wordList.Watch(ctx, cfg.WatchInterval)
+	}
 
-	// Use WORDLE_HOST or default to 0.0.0.0 for cloud deployment
-	host := os.Getenv("WORDLE_HOST")
-	if host == "" {
-		host = "0.0.0.0"
+	srv := &http.Server{
+		Handler:      routes(logger, wordList, cfg),
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
 	}
 
-	// Initialize dictionary
-	_, _ = fmt.Fprintf(os.Stderr, "Loading dictionary from %s\n", dict)
-	wordList, err := dictionary.NewWordList(os.Stderr, dict, remove)
+	ln, err := net.Listen("tcp", cfg.Addr())
 	if err != nil {
-		return fmt.Errorf("failed to load dictionary: %w", err)
+		return fmt.Errorf("server error: %w", err)
 	}
+	logger.Info("Starting Wordle Helper server", "address", ln.Addr().String())
+	_, _ = fmt.Fprintf(stderr, "Server running at http://%s\n", ln.Addr())
 
-	// Set up logging
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
 
-	// Set up routes
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server error: %w", err)
+	case <-ctx.Done():
+	}
+
+	logger.Info("Shutting down, waiting for requests to finish", "timeout", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server error: %w", err)
+	}
+	logger.Info("Server stopped")
+	return nil
+}
+
+// reloadOnHangup reloads the dictionary whenever the server gets SIGHUP,
+// until ctx is done.
+func reloadOnHangup(ctx context.Context, logger *slog.Logger, wordList *dictionary.WordList) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			logger.Info("Reloading dictionary on SIGHUP")
+			if err := wordList.Reload(); err != nil {
+				logger.Error("Keeping the current dictionary", "error", err)
+			}
+		}
+	}
+}
+
+// routes sets up the server's handlers.
+func routes(logger *slog.Logger, wordList *dictionary.WordList, cfg Config) http.Handler {
 	mux := http.NewServeMux()
 
 	// Static files (must be registered before more specific routes in Go 1.22)
-	fs := http.FileServer(http.Dir("web/static"))
-	mux.Handle("GET /static/", http.StripPrefix("/static/", fs))
+	static := web.Static()
+	if cfg.StaticDir != "" {
+		static = os.DirFS(cfg.StaticDir)
+	}
+	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
 
 	// Main page
-	mux.HandleFunc("GET /", handlers.HandleGetForm(logger))
+	mux.HandleFunc("GET /", handlers.HandleGetForm(logger, wordList))
 
 	// Solve endpoint
 	mux.HandleFunc("POST /wordle/solve", handlers.HandlePostSolve(logger, wordList))
 
+	// Shared game replay endpoint
+	mux.HandleFunc("POST /wordle/share", handlers.HandlePostShare(logger, wordList))
 
-	// Start server
-	addr := host + ":" + port
-	logger.Info("Starting Wordle Helper server", "address", addr)
-	_, _ = fmt.Fprintf(os.Stderr, "Server running at http://%s\n", addr)
+	// JSON API
+	mux.HandleFunc("POST /api/v1/solve", handlers.HandleAPISolve(logger, wordList))
+	mux.HandleFunc("POST /api/v1/suggest", handlers.HandleAPISuggest(logger, wordList))
+	mux.HandleFunc("POST /api/v1/score", handlers.HandleAPIScore(logger))
 
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		return fmt.Errorf("server error: %w", err)
+	// Admin endpoints, only when a token is configured
+	if cfg.AdminToken == "" {
+		logger.Info("Admin endpoints disabled, set WORDLE_ADMIN_TOKEN to enable them")
+	} else {
+		admin := func(h http.HandlerFunc) http.Handler {
+			return handlers.RequireToken(logger, cfg.AdminToken, h)
+		}
+		mux.Handle("POST /admin/reload", admin(handlers.HandleAdminReload(logger, wordList)))
+		mux.Handle("GET /admin/dictionary/stats", admin(handlers.HandleAdminStats(logger, wordList)))
+		mux.Handle("POST /admin/remove-word", admin(handlers.HandleAdminRemoveWord(logger, wordList)))
+		mux.Handle("POST /admin/add-word", admin(handlers.HandleAdminAddWord(logger, wordList)))
 	}
 
-	return nil
+	return mux
 }
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer that can be written by the server while the
+// test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var serverAddr = regexp.MustCompile(`Server running at (http://\S+)`)
+
+// startServer runs the server with args on a free port and returns its URL,
+// its stderr and a function that stops it and returns run's error.
+func startServer(t *testing.T, env map[string]string, args ...string) (string, *syncBuffer, func() error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	stderr := &syncBuffer{}
+	done := make(chan error, 1)
+	args = append([]string{"-host", "127.0.0.1", "-port", "0"}, args...)
+	go func() {
+		done <- run(ctx, args, func(k string) string { return env[k] }, io.Discard, stderr)
+	}()
+	stop := func() error {
+		cancel()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("server did not shut down")
+			return nil
+		}
+	}
+
+	var url string
+	waitFor(t, stderr, func(s string) bool {
+		if m := serverAddr.FindStringSubmatch(s); m != nil {
+			url = m[1]
+		}
+		return url != ""
+	})
+	return url, stderr, stop
+}
+
+// waitFor waits until ready reports true for what the server has written to
+// stderr.
+func waitFor(t *testing.T, stderr *syncBuffer, ready func(string) bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !ready(stderr.String()); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the server: %s", stderr.String())
+		}
+	}
+}
+
+func writeWords(t *testing.T, path string, words ...string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(words, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	answers := filepath.Join(t.TempDir(), "answers")
+	writeWords(t, answers, "crane", "guide", "split")
+	url, _, stop := startServer(t, map[string]string{"WORDLE_ANSWERS": answers})
+
+	res, err := http.Post(url+"/api/v1/score", "application/json", strings.NewReader(`{"guess":"crane","answer":"guide"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusOK || !strings.Contains(string(body), `"pattern":"xxxxg"`) {
+		t.Errorf("score = %d %s", res.StatusCode, body)
+	}
+
+	if err := stop(); err != nil {
+		t.Errorf("run() = %v, want nil after shutdown", err)
+	}
+}
+
+func TestRunWatchesDictionary(t *testing.T) {
+	answers := filepath.Join(t.TempDir(), "answers")
+	writeWords(t, answers, "crane", "guide")
+	_, stderr, stop := startServer(t, map[string]string{"WORDLE_ANSWERS": answers}, "-watch", "10ms")
+	defer func() { _ = stop() }()
+
+	// Make sure the new file's modification time differs from the old one's
+	later := time.Now().Add(time.Second)
+	writeWords(t, answers, "crane", "guide", "split")
+	if err := os.Chtimes(answers, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, stderr, func(s string) bool {
+		return strings.Contains(s, "3 answers (+1)")
+	})
+}
+
+func TestRunAdmin(t *testing.T) {
+	dir := t.TempDir()
+	answers := filepath.Join(dir, "answers")
+	remove := filepath.Join(dir, "remove")
+	writeWords(t, answers, "crane", "guide", "split")
+	writeWords(t, remove, "split")
+	const token = "0123456789abcdef"
+	url, _, stop := startServer(t, map[string]string{
+		"WORDLE_ANSWERS":     answers,
+		"WORDLE_REMOVE":      remove,
+		"WORDLE_ADMIN_TOKEN": token,
+	})
+	defer func() { _ = stop() }()
+
+	call := func(method, path, auth, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, url+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", "Bearer "+auth)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = res.Body.Close() }()
+		data, _ := io.ReadAll(res.Body)
+		return res.StatusCode, string(data)
+	}
+
+	if code, body := call("GET", "/admin/dictionary/stats", "", ""); code != http.StatusUnauthorized {
+		t.Errorf("stats without token = %d %s, want 401", code, body)
+	}
+	if code, body := call("GET", "/admin/dictionary/stats", "wrong", ""); code != http.StatusUnauthorized {
+		t.Errorf("stats with wrong token = %d %s, want 401", code, body)
+	}
+	if code, body := call("GET", "/admin/dictionary/stats", token, ""); code != http.StatusOK || !strings.Contains(body, `"answers":2`) {
+		t.Errorf("stats = %d %s, want 2 answers", code, body)
+	}
+
+	if code, body := call("POST", "/admin/remove-word", token, `{"word":"guide"}`); code != http.StatusOK || !strings.Contains(body, `"answers":1`) {
+		t.Errorf("remove-word = %d %s, want 1 answer", code, body)
+	}
+	if data, _ := os.ReadFile(remove); string(data) != "guide\nsplit\n" {
+		t.Errorf("remove list = %q, want guide and split", data)
+	}
+
+	if code, body := call("POST", "/admin/add-word", token, `{"word":"split"}`); code != http.StatusOK || !strings.Contains(body, `"answers":2`) {
+		t.Errorf("add-word = %d %s, want 2 answers", code, body)
+	}
+	if code, body := call("POST", "/admin/add-word", token, `{"word":"zzzzz"}`); code != http.StatusNotFound {
+		t.Errorf("add-word of unknown word = %d %s, want 404", code, body)
+	}
+	if code, body := call("POST", "/admin/reload", token, ""); code != http.StatusOK || !strings.Contains(body, `"removed":1`) {
+		t.Errorf("reload = %d %s, want 1 removed", code, body)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	env := map[string]string{"WORDLE_DICTIONARY": "words", "PORT": "9000", "WORDLE_HOST": "localhost"}
+	getenv := func(k string) string { return env[k] }
+
+	cfg, err := loadConfig(nil, getenv, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Answers != "words" || cfg.Addr() != "localhost:9000" || cfg.StaticDir != "" || cfg.Length != 5 || cfg.Alphabet.Name != "english" {
+		t.Errorf("config from env = %+v", cfg)
+	}
+
+	cfg, err = loadConfig([]string{"-port", "8081", "-answers", "other", "-length", "6", "-alphabet", "spanish", "-fold"}, getenv, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Answers != "other" || cfg.Port != "8081" || cfg.Length != 6 || cfg.Alphabet.Name != "spanish" || !cfg.Alphabet.Fold {
+		t.Errorf("flags did not override env: %+v", cfg)
+	}
+
+	for _, args := range [][]string{
+		{"-port", "http"},
+		{"-port", "70000"},
+		{"-write-timeout", "0s"},
+		{"-watch", "-1s"},
+		{"-length", "2"},
+		{"-length", "11"},
+		{"-alphabet", "klingon"},
+		{"extra"},
+	} {
+		if _, err := loadConfig(args, getenv, io.Discard); err == nil {
+			t.Errorf("loadConfig(%q) error = nil, want error", args)
+		}
+	}
+
+	env["WORDLE_ADMIN_TOKEN"] = "secret"
+	if _, err := loadConfig(nil, getenv, io.Discard); err == nil {
+		t.Errorf("loadConfig() with a short admin token error = nil, want error")
+	}
+}
@@ -1,18 +1,32 @@
 package components
 
 import (
+	"fmt"
+
 	g "github.com/maragudk/gomponents"
 	"github.com/maragudk/gomponents/html"
+	"wordle/wordle"
 )
 
 // FormData represents the form input from the user
 type FormData struct {
-	Missed string
-	Pos0   string
-	Pos1   string
-	Pos2   string
-	Pos3   string
-	Pos4   string
+	Rows    []GuessRow
+	Guesses string
+	Missed  string
+	// Positions has one entry per letter of the word
+	Positions []string
+	Counts    string
+	Strategy  string
+	// HasHistory says the past answers are known, so they can be left out
+	HasHistory  bool
+	ExcludePast bool
+}
+
+// strategyLabels describes each suggestion strategy for the strategy selector
+var strategyLabels = map[string]string{
+	"entropy":  "Most information (entropy)",
+	"minimax":  "Smallest worst case (minimax)",
+	"expected": "Fewest words left on average",
 }
 
 // WordleForm renders the main Wordle helper form
@@ -23,6 +37,7 @@ func WordleForm(data FormData, errorMsg string) g.Node {
 			InstructionsCard(),
 			FormCard(data),
 			html.Div(html.ID("results-section")),
+			ShareForm(ShareFormData{}),
 		),
 	)
 }
@@ -49,6 +64,11 @@ func InstructionsCard() g.Node {
 		html.H5(html.Class("alert-heading"), g.Text("📖 How to use:")),
 		html.Div(html.Class("instructions"),
 			html.P(html.Class("mb-2"),
+				html.Strong(g.Text("Guesses: ")),
+				g.Text("Type each guess into the grid and click its tiles until the colors match your game. Each row shows how many words were left after it."),
+			),
+			html.P(html.Class("mb-2"),
+				html.Strong(g.Text("Or enter the clues yourself. ")),
 				html.Strong(g.Text("Missed Letters: ")),
 				g.Text("Enter letters that are NOT in the word (gray squares ⬛)"),
 			),
@@ -68,6 +88,9 @@ func InstructionsCard() g.Node {
 				g.Text(", Position 3: "), html.Code(g.Text("a")),
 				g.Text(", Position 4: "), html.Code(g.Text(".")),
 				g.Text(", Position 5: "), html.Code(g.Text(".")),
+				html.Br(),
+				g.Text("→ or type "), html.Code(g.Text("crane")),
+				g.Text(" in the grid and click R once and A twice"),
 			),
 		),
 	)
@@ -83,6 +106,50 @@ func FormCard(data FormData) g.Node {
 			g.Attr("hx-target", "#results-section"),
 			g.Attr("hx-indicator", "#loading"),
 
+			// Word length selector. Changing it fetches the form for the new
+			// length, so the grid and position boxes have a tile per letter
+			html.Div(html.Class("mb-4"),
+				html.Label(html.For("length"), html.Class("form-label fw-bold"), g.Text("Word Length")),
+				html.Select(
+					html.Class("form-select"),
+					html.ID("length"),
+					html.Name("length"),
+					g.Attr("hx-get", "/"),
+					g.Attr("hx-select", ".form-card"),
+					g.Attr("hx-target", "closest .form-card"),
+					g.Attr("hx-swap", "outerHTML"),
+					g.Group(lengthOptions(len(data.Positions))),
+				),
+			),
+
+			// Guess grid
+			html.Div(html.Class("mb-4"),
+				html.Label(html.Class("form-label fw-bold"), g.Text("Your Guesses")),
+				GuessGrid(data.Rows, len(data.Positions)),
+				html.Div(html.Class("form-text"),
+					g.Text("Type each guess, then click its tiles to match the game: gray, yellow, green."),
+				),
+			),
+
+			// Pasted guesses field
+			html.Details(html.Class("mb-4"), g.If(data.Guesses != "", g.Attr("open")),
+				html.Summary(html.Class("form-label fw-bold"), g.Text("Paste Guesses (optional)")),
+				html.Textarea(
+					html.Class("form-control font-monospace"),
+					html.ID("guesses"),
+					html.Name("guesses"),
+					html.Rows("3"),
+					html.Placeholder("crane xygxx\nslate ⬛🟨🟩⬛⬛"),
+					g.Attr("autocomplete", "off"),
+					g.Text(data.Guesses),
+				),
+				html.Div(html.Class("form-text"),
+					g.Text("One guess per line followed by its colors: "), html.Code(g.Text("g")), g.Text(" green, "),
+					html.Code(g.Text("y")), g.Text(" yellow, "), html.Code(g.Text("x")),
+					g.Text(" gray, or paste the emoji squares. They're combined with the other clues, not shown in the grid."),
+				),
+			),
+
 			// Missed Letters field
 			html.Div(html.Class("mb-4"),
 				html.Label(html.For("missed"), html.Class("form-label fw-bold"), g.Text("Missed Letters (not in word)")),
@@ -95,18 +162,14 @@ func FormCard(data FormData) g.Node {
 					html.Placeholder("e.g., xyz"),
 					g.Attr("autocomplete", "off"),
 				),
-				html.Div(html.Class("form-text"), g.Text("Enter all letters that appeared gray (not in the word)")),
+				html.Div(html.Class("form-text"), g.Text("Enter all letters that appeared gray (not in the word). A gray letter that is also green or yellow limits the word to the copies you've found.")),
 			),
 
 			// Position inputs
 			html.Div(html.Class("mb-4"),
-				html.Label(html.Class("form-label fw-bold"), g.Text("Word Positions (1-5)")),
+				html.Label(html.Class("form-label fw-bold"), g.Textf("Word Positions (1-%d)", len(data.Positions))),
 				html.Div(html.Class("d-flex justify-content-center gap-3 flex-wrap"),
-					PositionInput("pos0", data.Pos0, "1"),
-					PositionInput("pos1", data.Pos1, "2"),
-					PositionInput("pos2", data.Pos2, "3"),
-					PositionInput("pos3", data.Pos3, "4"),
-					PositionInput("pos4", data.Pos4, "5"),
+					g.Group(positionInputs(data.Positions)),
 				),
 				html.Div(html.Class("form-text text-center mt-2"),
 					g.Text("Green (correct): "), html.Code(g.Text("a")),
@@ -115,6 +178,52 @@ func FormCard(data FormData) g.Node {
 				),
 			),
 
+			// Letter counts field
+			html.Div(html.Class("mb-4"),
+				html.Label(html.For("counts"), html.Class("form-label fw-bold"), g.Text("Letter Counts (optional)")),
+				html.Input(
+					html.Type("text"),
+					html.Class("form-control"),
+					html.ID("counts"),
+					html.Name("counts"),
+					html.Value(data.Counts),
+					html.Placeholder("e.g., e=1 s>=2"),
+					g.Attr("autocomplete", "off"),
+				),
+				html.Div(html.Class("form-text"),
+					g.Text("For repeated letters: "), html.Code(g.Text("e=1")),
+					g.Text(" means exactly one e, "), html.Code(g.Text("e>=2")),
+					g.Text(" means at least two"),
+				),
+			),
+
+			// Strategy selector
+			html.Div(html.Class("mb-4"),
+				html.Label(html.For("strategy"), html.Class("form-label fw-bold"), g.Text("Suggestion Strategy")),
+				html.Select(
+					html.Class("form-select"),
+					html.ID("strategy"),
+					html.Name("strategy"),
+					g.Group(g.Map(wordle.Strategies(), func(s wordle.Strategy) g.Node {
+						return html.Option(html.Value(s.Name()), g.If(s.Name() == data.Strategy, html.Selected()), g.Text(strategyLabels[s.Name()]))
+					})),
+				),
+			),
+
+			// Past answers toggle
+			g.If(data.HasHistory, html.Div(html.Class("form-check mb-4"),
+				html.Input(
+					html.Type("checkbox"),
+					html.Class("form-check-input"),
+					html.ID("exclude_past"),
+					html.Name("exclude_past"),
+					html.Value("on"),
+					g.If(data.ExcludePast, html.Checked()),
+				),
+				html.Label(html.For("exclude_past"), html.Class("form-check-label fw-bold"), g.Text("Leave out past answers")),
+				html.Div(html.Class("form-text"), g.Text("Words that were already the answer almost never come back.")),
+			)),
+
 			// Submit button
 			html.Div(html.Class("text-center"),
 				html.Button(
@@ -132,6 +241,26 @@ func FormCard(data FormData) g.Node {
 	)
 }
 
+// lengthOptions renders an option for each word length that can be played,
+// with length selected
+func lengthOptions(length int) []g.Node {
+	var nodes []g.Node
+	for n := wordle.MinWordLength; n <= wordle.MaxWordLength; n++ {
+		nodes = append(nodes, html.Option(html.Value(fmt.Sprint(n)), g.If(n == length, html.Selected()), g.Textf("%d letters", n)))
+	}
+	return nodes
+}
+
+// positionInputs renders an input box for each position, named pos0, pos1 and
+// so on
+func positionInputs(positions []string) []g.Node {
+	nodes := make([]g.Node, len(positions))
+	for i, value := range positions {
+		nodes[i] = PositionInput(fmt.Sprintf("pos%d", i), value, fmt.Sprint(i+1))
+	}
+	return nodes
+}
+
 // PositionInput renders a single position input box
 func PositionInput(name, value, label string) g.Node {
 	return html.Div(
@@ -0,0 +1,137 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	g "github.com/maragudk/gomponents"
+	"github.com/maragudk/gomponents/html"
+	"wordle/wordle"
+)
+
+// GridRows is how many guess rows the grid shows, one per Wordle guess.
+const GridRows = 6
+
+// GuessRow is one row of the guess grid
+type GuessRow struct {
+	Word    string
+	Pattern string // one of g, y or x per tile
+	// Remaining is how many words were possible after this row, or -1 if the
+	// row hasn't been counted.
+	Remaining int
+}
+
+// EmptyGuessRows returns a grid of blank rows for words of length letters
+func EmptyGuessRows(length int) []GuessRow {
+	rows := make([]GuessRow, GridRows)
+	for i := range rows {
+		rows[i] = GuessRow{Pattern: strings.Repeat("x", length), Remaining: -1}
+	}
+	return rows
+}
+
+// GuessGrid renders a Wordle-style board. Each row has the guessed word and
+// tiles that cycle gray, yellow and green when clicked; the colors are kept
+// in a hidden pattern field so every row is submitted with the form. Each row
+// has length tiles.
+func GuessGrid(rows []GuessRow, length int) g.Node {
+	if length == 0 {
+		length = wordle.WordLength
+	}
+	if len(rows) == 0 {
+		rows = EmptyGuessRows(length)
+	}
+	nodes := make([]g.Node, len(rows))
+	for i, row := range rows {
+		nodes[i] = guessRow(i, row, length)
+	}
+	return html.Div(html.Class("guess-grid mb-2"),
+		g.Group(nodes),
+		html.Script(g.Raw(gridScript)),
+	)
+}
+
+func guessRow(index int, row GuessRow, length int) g.Node {
+	pattern := row.Pattern
+	if len(pattern) != length {
+		pattern = strings.Repeat("x", length)
+	}
+	letters := []rune(row.Word)
+	tiles := make([]g.Node, length)
+	for i := range tiles {
+		letter := ""
+		if i < len(letters) {
+			letter = string(letters[i])
+		}
+		tiles[i] = html.Button(
+			html.Type("button"),
+			html.Class("tile tile-"+pattern[i:i+1]),
+			g.Attr("data-pos", fmt.Sprint(i)),
+			g.Attr("aria-label", fmt.Sprintf("Letter %d color", i+1)),
+			g.Text(letter),
+		)
+	}
+
+	return html.Div(html.Class("guess-row d-flex align-items-center gap-2 mb-2"),
+		html.Input(
+			html.Type("text"),
+			html.Class("form-control guess-word"),
+			html.Name("guess"),
+			html.Value(row.Word),
+			g.Attr("maxlength", fmt.Sprint(length)),
+			html.Placeholder(fmt.Sprintf("guess %d", index+1)),
+			g.Attr("autocomplete", "off"),
+			g.Attr("aria-label", fmt.Sprintf("Guess %d", index+1)),
+		),
+		html.Input(html.Type("hidden"), html.Name("pattern"), html.Value(pattern)),
+		html.Div(html.Class("d-flex gap-1"), g.Group(tiles)),
+		RowCount(index, row.Remaining, false),
+	)
+}
+
+// RowCount renders how many words were left after a grid row. With oob set it
+// replaces the count already on the page when returned from an HTMX request.
+func RowCount(index, remaining int, oob bool) g.Node {
+	text := ""
+	if remaining >= 0 {
+		text = fmt.Sprintf("%d left", remaining)
+	}
+	return html.Span(
+		html.ID(fmt.Sprintf("row-count-%d", index)),
+		html.Class("row-count"),
+		g.If(oob, g.Attr("hx-swap-oob", "true")),
+		g.Text(text),
+	)
+}
+
+// RowCounts renders the count for every grid row for an out-of-band swap
+func RowCounts(rows []GuessRow) g.Node {
+	nodes := make([]g.Node, len(rows))
+	for i, row := range rows {
+		nodes[i] = RowCount(i, row.Remaining, true)
+	}
+	return g.Group(nodes)
+}
+
+// gridScript keeps the tiles in step with the typed words and cycles a tile's
+// color when it's clicked. The listeners are on the document so they keep
+// working after HTMX swaps.
+const gridScript = `
+document.addEventListener('input', function (e) {
+    if (!e.target.matches('.guess-word')) return;
+    var word = e.target.value.toLowerCase();
+    e.target.closest('.guess-row').querySelectorAll('.tile').forEach(function (tile, i) {
+        tile.textContent = word.charAt(i);
+    });
+});
+document.addEventListener('click', function (e) {
+    var tile = e.target.closest('.tile');
+    if (!tile) return;
+    var pattern = tile.closest('.guess-row').querySelector('input[name=pattern]');
+    var tiles = pattern.value.split('');
+    var pos = Number(tile.dataset.pos);
+    tiles[pos] = {x: 'y', y: 'g', g: 'x'}[tiles[pos]] || 'x';
+    pattern.value = tiles.join('');
+    tile.className = 'tile tile-' + tiles[pos];
+});
+`
@@ -104,6 +104,41 @@ body {
     text-align: center;
 }
 
+.guess-word {
+    width: 110px;
+    font-family: monospace;
+    text-transform: lowercase;
+}
+
+.tile {
+    width: 44px;
+    height: 44px;
+    border: none;
+    border-radius: 4px;
+    color: white;
+    font-size: 22px;
+    font-weight: bold;
+    text-transform: uppercase;
+}
+
+.tile-x {
+    background-color: var(--wordle-gray);
+}
+
+.tile-y {
+    background-color: var(--wordle-yellow);
+}
+
+.tile-g {
+    background-color: var(--wordle-green);
+}
+
+.row-count {
+    font-size: 14px;
+    color: #6c757d;
+    min-width: 70px;
+}
+
 .btn-solve {
     background-color: var(--wordle-green);
     border-color: var(--wordle-green);
@@ -3,6 +3,7 @@ package components
 import (
 	g "github.com/maragudk/gomponents"
 	"github.com/maragudk/gomponents/html"
+	"wordle/wordle"
 )
 
 // hasRepeatedLetters checks if a word has any repeated letters
@@ -30,8 +31,9 @@ func separateWordsByRepetition(words []string) (noRepeats []string, withRepeats
 	return noRepeats, withRepeats
 }
 
-// Results renders the results section with found words
-func Results(words []string, count int) g.Node {
+// Results renders the results section with found words and suggested next guesses.
+// byLikelihood says the words are sorted with the most likely answers first.
+func Results(words []string, count int, strategy string, suggestions []wordle.Suggestion, byLikelihood bool) g.Node {
 	if count == 0 {
 		return ResultsCard(
 			html.H3(html.Class("mb-3"),
@@ -45,9 +47,6 @@ func Results(words []string, count int) g.Node {
 		)
 	}
 
-	// Separate words into two groups
-	noRepeats, withRepeats := separateWordsByRepetition(words)
-
 	var tip g.Node
 	if count > 100 {
 		tip = html.Div(html.Class("alert alert-info mt-3 mb-0"),
@@ -55,41 +54,20 @@ func Results(words []string, count int) g.Node {
 		)
 	}
 
-	// Build sections for each group
+	// Build sections for each group. Words sorted by likelihood stay in one
+	// group, so a common word with a repeated letter isn't put after every
+	// rare word without one.
 	var sections []g.Node
-
-	// Section for words without repeated letters
-	if len(noRepeats) > 0 {
-		sections = append(sections,
-			html.Div(html.Class("word-section mb-4"),
-				html.H5(html.Class("mb-2"),
-					g.Text("No Repeated Letters "),
-					html.Span(html.Class("badge bg-primary"), g.Textf("%d", len(noRepeats))),
-				),
-				html.Div(html.Class("word-list"),
-					g.Group(g.Map(noRepeats, func(word string) g.Node {
-						return html.Span(html.Class("word-badge"), g.Text(word))
-					})),
-				),
-			),
-		)
-	}
-
-	// Section for words with repeated letters
-	if len(withRepeats) > 0 {
-		sections = append(sections,
-			html.Div(html.Class("word-section"),
-				html.H5(html.Class("mb-2"),
-					g.Text("Repeated Letters "),
-					html.Span(html.Class("badge bg-secondary"), g.Textf("%d", len(withRepeats))),
-				),
-				html.Div(html.Class("word-list"),
-					g.Group(g.Map(withRepeats, func(word string) g.Node {
-						return html.Span(html.Class("word-badge"), g.Text(word))
-					})),
-				),
-			),
-		)
+	if byLikelihood {
+		sections = append(sections, wordSection("word-section", "Most Likely First ", "bg-primary", words))
+	} else {
+		noRepeats, withRepeats := separateWordsByRepetition(words)
+		if len(noRepeats) > 0 {
+			sections = append(sections, wordSection("word-section mb-4", "No Repeated Letters ", "bg-primary", noRepeats))
+		}
+		if len(withRepeats) > 0 {
+			sections = append(sections, wordSection("word-section", "Repeated Letters ", "bg-secondary", withRepeats))
+		}
 	}
 
 	return ResultsCard(
@@ -97,13 +75,64 @@ func Results(words []string, count int) g.Node {
 			g.Text("Possible Words "),
 			html.Span(html.Class("badge bg-success"), g.Textf("%d found", count)),
 		),
+		Suggestions(strategy, suggestions),
 		g.Group(sections),
 		tip,
 	)
 }
 
+// wordSection renders a titled group of words with a badge counting them
+func wordSection(class, title, badge string, words []string) g.Node {
+	return html.Div(html.Class(class),
+		html.H5(html.Class("mb-2"),
+			g.Text(title),
+			html.Span(html.Class("badge "+badge), g.Textf("%d", len(words))),
+		),
+		html.Div(html.Class("word-list"),
+			g.Group(g.Map(words, func(word string) g.Node {
+				return html.Span(html.Class("word-badge"), g.Text(word))
+			})),
+		),
+	)
+}
+
+// Suggestions renders the best next guesses ranked by the named strategy
+func Suggestions(strategy string, suggestions []wordle.Suggestion) g.Node {
+	if len(suggestions) == 0 {
+		return nil
+	}
+	return html.Div(html.Class("word-section mb-4"),
+		html.H5(html.Class("mb-2"),
+			g.Text("Suggested Next Guesses "),
+			html.Span(html.Class("badge bg-secondary"), g.Text(strategy)),
+		),
+		html.Table(html.Class("table table-sm suggestions-table mb-1"),
+			html.THead(
+				html.Tr(
+					html.Th(g.Text("Guess")),
+					html.Th(html.Class("text-end"), g.Text("Information")),
+					html.Th(html.Class("text-end"), g.Text("Expected Words Left")),
+					html.Th(html.Class("text-end"), g.Text("Worst Case")),
+				),
+			),
+			html.TBody(
+				g.Group(g.Map(suggestions, func(s wordle.Suggestion) g.Node {
+					return html.Tr(
+						html.Td(
+							html.Span(html.Class("word-badge"), g.Text(s.Word)),
+							g.If(s.Candidate, html.Span(html.Class("badge bg-success ms-1"), g.Text("possible answer"))),
+						),
+						html.Td(html.Class("text-end align-middle"), g.Textf("%.2f bits", s.Entropy)),
+						html.Td(html.Class("text-end align-middle"), g.Textf("%.1f", s.ExpectedRemaining)),
+						html.Td(html.Class("text-end align-middle"), g.Textf("%d", s.WorstCase)),
+					)
+				})),
+			),
+		),
+	)
+}
+
 // ResultsCard wraps results in a card
 func ResultsCard(children ...g.Node) g.Node {
 	return html.Div(html.Class("results-card"), g.Group(children))
 }
-
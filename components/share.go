@@ -0,0 +1,125 @@
+package components
+
+import (
+	"fmt"
+
+	g "github.com/maragudk/gomponents"
+	"github.com/maragudk/gomponents/html"
+	"wordle/share"
+)
+
+// ShareFormData represents the share text form input from the user
+type ShareFormData struct {
+	Text    string
+	Guesses string
+	Answer  string
+}
+
+// ShareForm renders the form for replaying pasted NYT share text
+func ShareForm(data ShareFormData) g.Node {
+	return html.Div(html.Class("form-card"),
+		html.H5(html.Class("mb-3"), g.Text("📋 Replay a Shared Game")),
+		html.Form(
+			html.Method("POST"),
+			html.Action("/wordle/share"),
+			g.Attr("hx-post", "/wordle/share"),
+			g.Attr("hx-target", "#share-results"),
+			g.Attr("hx-indicator", "#share-loading"),
+
+			html.Div(html.Class("mb-3"),
+				html.Label(html.For("share-text"), html.Class("form-label fw-bold"), g.Text("Share Text")),
+				html.Textarea(
+					html.Class("form-control font-monospace"),
+					html.ID("share-text"),
+					html.Name("text"),
+					html.Rows("7"),
+					html.Placeholder("Wordle 1,234 4/6\n\n⬛🟨⬛⬛⬛\n..."),
+					g.Text(data.Text),
+				),
+			),
+			html.Div(html.Class("row mb-3"),
+				html.Div(html.Class("col-md-8"),
+					html.Label(html.For("share-guesses"), html.Class("form-label fw-bold"), g.Text("Guesses (optional)")),
+					html.Input(
+						html.Type("text"),
+						html.Class("form-control"),
+						html.ID("share-guesses"),
+						html.Name("guesses"),
+						html.Value(data.Guesses),
+						html.Placeholder("e.g., crane split guide"),
+						g.Attr("autocomplete", "off"),
+					),
+				),
+				html.Div(html.Class("col-md-4"),
+					html.Label(html.For("share-answer"), html.Class("form-label fw-bold"), g.Text("Answer (optional)")),
+					html.Input(
+						html.Type("text"),
+						html.Class("form-control"),
+						html.ID("share-answer"),
+						html.Name("answer"),
+						html.Value(data.Answer),
+						html.Placeholder("e.g., guide"),
+						g.Attr("autocomplete", "off"),
+					),
+				),
+				html.Div(html.Class("form-text"), g.Text("Give the guesses, the answer, or both to see how many words were left after each row.")),
+			),
+			html.Div(html.Class("text-center"),
+				html.Button(
+					html.Type("submit"),
+					html.Class("btn btn-solve"),
+					g.Text("Replay"),
+				),
+				html.Div(html.ID("share-loading"), html.Class("htmx-indicator ms-2"),
+					html.Div(html.Class("spinner-border text-success"), html.Role("status"),
+						html.Span(html.Class("visually-hidden"), g.Text("Loading...")),
+					),
+				),
+			),
+		),
+		html.Div(html.ID("share-results"), html.Class("mt-3")),
+	)
+}
+
+// ShareResults renders how many words were left after each row of a shared game
+func ShareResults(result share.Result, steps []share.Step) g.Node {
+	score := "X"
+	if result.Solved() {
+		score = fmt.Sprint(result.Score)
+	}
+	guessesKnown := len(steps) > 0 && steps[0].Guess != ""
+
+	return ResultsCard(
+		html.H4(html.Class("mb-3"),
+			g.Textf("Wordle %d %s/%d", result.Number, score, result.MaxGuesses),
+			g.If(result.HardMode, html.Span(html.Class("badge bg-secondary ms-2"), g.Text("hard mode"))),
+		),
+		g.If(len(steps) == 0,
+			html.Div(html.Class("alert alert-info mb-0"),
+				g.Text("Give the guesses or the answer to see how many words were left after each row."),
+			),
+		),
+		g.If(len(steps) > 0,
+			html.Table(html.Class("table table-sm mb-0"),
+				html.THead(
+					html.Tr(
+						html.Th(g.Text("Row")),
+						g.If(guessesKnown, html.Th(g.Text("Guess"))),
+						html.Th(html.Class("text-end"), g.If(guessesKnown, g.Text("Words Left")), g.If(!guessesKnown, g.Text("Words Left (at most)"))),
+						g.If(!guessesKnown, html.Th(html.Class("text-end"), g.Text("Possible Guesses"))),
+					),
+				),
+				html.TBody(
+					g.Group(g.Map(steps, func(step share.Step) g.Node {
+						return html.Tr(
+							html.Td(g.Text(step.Pattern.Emoji())),
+							g.If(guessesKnown, html.Td(html.Span(html.Class("word-badge"), g.Text(step.Guess)))),
+							html.Td(html.Class("text-end align-middle"), g.Textf("%d", step.Remaining)),
+							g.If(!guessesKnown, html.Td(html.Class("text-end align-middle"), g.Textf("%d", len(step.Guesses)))),
+						)
+					})),
+				),
+			),
+		),
+	)
+}
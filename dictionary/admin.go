@@ -0,0 +1,211 @@
+package dictionary
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoRemoveList is returned when words are removed or restored but the
+	// remove list isn't a file on disk.
+	ErrNoRemoveList = errors.New("no remove list file is configured")
+	// ErrNotInDictionary is returned for a word that isn't in the word files,
+	// so it can't be removed or restored.
+	ErrNotInDictionary = errors.New("word is not in the dictionary")
+)
+
+// Stats describes the words currently loaded.
+type Stats struct {
+	Length  int // letters in each word
+	Answers int
+	Guesses int
+	Removed int
+	// The lists the words came from, as described by Source.String
+	AnswersSource     string
+	GuessesSource     string
+	RemoveSource      string
+	FrequenciesSource string
+	HistorySource     string
+	LoadedAt          time.Time
+}
+
+// Stats returns the number of words loaded and where they came from.
+func (wl *WordList) Stats() (Stats, error) {
+	removed, err := wl.removeList()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return Stats{
+		Length:            wl.length,
+		Answers:           len(wl.lists.Answers),
+		Guesses:           len(wl.lists.Guesses),
+		Removed:           len(removed),
+		AnswersSource:     wl.answers.String(),
+		GuessesSource:     wl.guesses.String(),
+		RemoveSource:      wl.remove.String(),
+		FrequenciesSource: wl.freqs.String(),
+		HistorySource:     wl.history.String(),
+		LoadedAt:          wl.loadedAt,
+	}, nil
+}
+
+// RemoveWord adds word to the remove list file and reloads, so the word is no
+// longer an answer or a guess. Removing a word that is already removed does
+// nothing.
+func (wl *WordList) RemoveWord(word string) error {
+	if wl.remove.Path == "" {
+		return ErrNoRemoveList
+	}
+	wl.editMu.Lock()
+	defer wl.editMu.Unlock()
+
+	removed, err := wl.removeList()
+	if err != nil {
+		return err
+	}
+	if slices.Contains(removed, word) {
+		return nil
+	}
+	if !wl.loaded(word) {
+		return fmt.Errorf("%q: %w", word, ErrNotInDictionary)
+	}
+	return wl.saveRemoveList(append(removed, word))
+}
+
+// AddWord takes word off the remove list file and reloads, so it can be an
+// answer or a guess again. The word must be in the answer or guess file.
+// Adding a word that is already loaded does nothing.
+func (wl *WordList) AddWord(word string) error {
+	if wl.remove.Path == "" {
+		return ErrNoRemoveList
+	}
+	wl.editMu.Lock()
+	defer wl.editMu.Unlock()
+
+	if wl.loaded(word) {
+		return nil
+	}
+	removed, err := wl.removeList()
+	if err != nil {
+		return err
+	}
+	i := slices.Index(removed, word)
+	if i < 0 {
+		return fmt.Errorf("%q: %w", word, ErrNotInDictionary)
+	}
+	// Taking it off the remove list only helps if the word files have it
+	inFiles, err := wl.inWordFiles(word)
+	if err != nil {
+		return err
+	}
+	if !inFiles {
+		return fmt.Errorf("%q: %w", word, ErrNotInDictionary)
+	}
+	return wl.saveRemoveList(slices.Delete(removed, i, i+1))
+}
+
+// loaded reports whether word was loaded from the word files and not removed.
+// Words of any length or alphabet count, not just the ones being played, so
+// the remove list can be edited whatever the server plays.
+func (wl *WordList) loaded(word string) bool {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return slices.Contains(wl.all.Guesses, word)
+}
+
+// saveRemoveList replaces the remove list file with words and reloads.
+func (wl *WordList) saveRemoveList(words []string) error {
+	slices.Sort(words)
+	var sb strings.Builder
+	for _, w := range words {
+		sb.WriteString(w)
+		sb.WriteString("\n")
+	}
+	if err := writeFileAtomic(wl.remove.Path, []byte(sb.String())); err != nil {
+		return fmt.Errorf("failed to save remove list: %w", err)
+	}
+	_, _ = fmt.Fprintf(wl.stderr, "Saved %d words to %s\n", len(words), wl.remove.Path)
+
+	return wl.Reload()
+}
+
+// removeList reads the remove list. A missing file is an empty list.
+func (wl *WordList) removeList() ([]string, error) {
+	if wl.remove.IsZero() {
+		return nil, nil
+	}
+	words, err := loadWords(io.Discard, wl.remove)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var nonEmpty []string
+	for _, w := range words {
+		if w != "" {
+			nonEmpty = append(nonEmpty, w)
+		}
+	}
+	return nonEmpty, err
+}
+
+// inWordFiles reports whether word is in the answer or guess file, whether or
+// not it has been removed.
+func (wl *WordList) inWordFiles(word string) (bool, error) {
+	for _, src := range []Source{wl.answers, wl.guesses} {
+		if src.IsZero() {
+			continue
+		}
+		words, err := loadDictionary(io.Discard, src)
+		if err != nil {
+			return false, err
+		}
+		if words[word] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// writeFileAtomic replaces the file at path with data. The data is written to
+// a temporary file in the same directory and renamed over path, so readers
+// see either the old file or the new one and never a partial write.
+func writeFileAtomic(path string, data []byte) error {
+	perm := fs.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Clean up if anything below fails; after the rename this is a no-op
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,71 @@
+package dictionary_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"wordle/dictionary"
+)
+
+func TestRemoveAndAddWord(t *testing.T) {
+	dir := t.TempDir()
+	answers := filepath.Join(dir, "answers")
+	remove := filepath.Join(dir, "remove")
+	if err := os.WriteFile(answers, []byte("crane\nsplit\nbingos\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(remove, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wl, err := dictionary.NewWordList(io.Discard, answers, "", remove)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Words of a length that isn't being played can be removed and added too
+	for _, word := range []string{"crane", "bingos"} {
+		if err := wl.RemoveWord(word); err != nil {
+			t.Errorf("RemoveWord(%q): %v", word, err)
+		}
+	}
+	if got := wl.Answers(); !slices.Equal(got, []string{"split"}) {
+		t.Errorf("Answers() after removing = %v, want [split]", got)
+	}
+	if got := wl.ListsOfLength(6).Answers; len(got) != 0 {
+		t.Errorf("six letter answers after removing bingos = %v, want none", got)
+	}
+	data, err := os.ReadFile(remove)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "bingos\ncrane\n" {
+		t.Errorf("remove list = %q, want bingos and crane", data)
+	}
+
+	if err := wl.AddWord("bingos"); err != nil {
+		t.Errorf("AddWord(bingos): %v", err)
+	}
+	if got := wl.ListsOfLength(6).Answers; !slices.Equal(got, []string{"bingos"}) {
+		t.Errorf("six letter answers after adding bingos = %v, want [bingos]", got)
+	}
+
+	// Removing a word twice, or adding one that's loaded, does nothing
+	if err := wl.RemoveWord("crane"); err != nil {
+		t.Errorf("RemoveWord(crane) again: %v", err)
+	}
+	if err := wl.AddWord("split"); err != nil {
+		t.Errorf("AddWord(split): %v", err)
+	}
+
+	for _, word := range []string{"zzzzz", "zzzzzz"} {
+		if err := wl.RemoveWord(word); !errors.Is(err, dictionary.ErrNotInDictionary) {
+			t.Errorf("RemoveWord(%q) = %v, want ErrNotInDictionary", word, err)
+		}
+		if err := wl.AddWord(word); !errors.Is(err, dictionary.ErrNotInDictionary) {
+			t.Errorf("AddWord(%q) = %v, want ErrNotInDictionary", word, err)
+		}
+	}
+}
@@ -3,46 +3,260 @@ package dictionary
 import (
 	"fmt"
 	"io"
-	"os"
+	"io/fs"
 	"slices"
-	"strings"
 	"unicode"
-	"wordle/scan"
+	"unicode/utf8"
+	"wordle/wordle"
 )
 
+// Lists holds the words that could be the answer and the words that may be
+// typed as a guess. Guesses always includes every answer.
+type Lists struct {
+	Answers []string
+	Guesses []string
+	// Length is the number of letters in every word, or 0 if the lists have
+	// words of any length. The Create functions leave it 0; see OfLength.
+	Length int
+	// Alphabet is the alphabet the words are in. The Create functions leave
+	// it the zero Alphabet, which has every letter; see InAlphabet.
+	Alphabet wordle.Alphabet
+	// Frequencies tells how common the words are, or is nil if that isn't
+	// known. The Create functions leave it nil; see LoadFrequencies.
+	Frequencies wordle.Frequencies
+	// History is the past official answers, or nil if they aren't known.
+	// The Create functions leave it nil; see LoadHistory.
+	History History
+}
+
+// OfLength returns the lists with only the words of n letters. Frequencies
+// and History are shared, not copied.
+func (l Lists) OfLength(n int) Lists {
+	l.Length = n
+	l.Answers = wordsOfLength(l.Answers, n)
+	l.Guesses = wordsOfLength(l.Guesses, n)
+	return l
+}
+
+func wordsOfLength(words []string, n int) []string {
+	var kept []string
+	for _, w := range words {
+		if wordle.Len(w) == n {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+// InAlphabet returns the lists with the words normalized to the alphabet,
+// leaving out those with letters that aren't in it. Words that become the
+// same, such as "esta" and "está" when accents are folded, are kept once, and
+// their frequencies are added up. The Create functions keep words in any
+// alphabet, so this is how a game picks its language.
+func (l Lists) InAlphabet(a wordle.Alphabet) Lists {
+	l.Alphabet = a
+	l.Answers = wordsInAlphabet(l.Answers, a)
+	l.Guesses = wordsInAlphabet(l.Guesses, a)
+	if l.Frequencies != nil {
+		freqs := make(wordle.Frequencies, len(l.Frequencies))
+		for word, f := range l.Frequencies {
+			if w, ok := a.Normalize(word); ok {
+				freqs[w] += f
+			}
+		}
+		l.Frequencies = freqs
+	}
+	if l.History != nil {
+		history := make(History, 0, len(l.History))
+		for _, past := range l.History {
+			if w, ok := a.Normalize(past.Word); ok {
+				past.Word = w
+				history = append(history, past)
+			}
+		}
+		l.History = history
+	}
+	return l
+}
+
+func wordsInAlphabet(words []string, a wordle.Alphabet) []string {
+	// Words that normalize to themselves stay sorted and unique, so the set is
+	// only needed once one of them changes
+	var kept []string
+	for i, word := range words {
+		w, ok := a.Normalize(word)
+		if !ok {
+			continue
+		}
+		if w != word {
+			set := make(map[string]bool, len(words))
+			for _, k := range kept {
+				set[k] = true
+			}
+			for _, word := range words[i:] {
+				if w, ok := a.Normalize(word); ok {
+					set[w] = true
+				}
+			}
+			return sortedWords(set)
+		}
+		kept = append(kept, w)
+	}
+	return kept
+}
+
+// Create loads the five letter English words in the file dict, leaving out the
+// words in the file remove. remove may be empty. The Create functions that
+// return Lists keep words of every length and alphabet instead.
 func Create(stderr io.Writer, dict, remove string) ([]string, error) {
+	return CreateFrom(stderr, FileSource(dict), FileSource(remove))
+}
+
+// CreateFS loads the five letter English words in the file dict in fsys, leaving out the words in
+// the file remove. remove may be empty.
+func CreateFS(stderr io.Writer, fsys fs.FS, dict, remove string) ([]string, error) {
+	return CreateFrom(stderr, FSSource(fsys, dict), FSSource(fsys, remove))
+}
+
+// CreateFrom loads the five letter English words in dict, leaving out the
+// words in remove, which may be the zero Source.
+func CreateFrom(stderr io.Writer, dict, remove Source) ([]string, error) {
 	loaded, err := loadDictionary(stderr, dict)
 	if err != nil {
 		return nil, err
 	}
-
-	// Remove words from the remove list
 	removeList, err := loadWords(stderr, remove)
 	if err != nil {
 		return nil, err
 	}
+	return wordleWords(removeWords(stderr, loaded, removeList)), nil
+}
+
+// CreateFromReader loads the five letter English words read from dict,
+// leaving out the words read from remove, which may be nil.
+func CreateFromReader(stderr io.Writer, dict, remove io.Reader) ([]string, error) {
+	loaded, err := readDictionary(stderr, dict, "dictionary")
+	if err != nil {
+		return nil, err
+	}
+	removeList, err := readRemoveList(stderr, remove)
+	if err != nil {
+		return nil, err
+	}
+	return wordleWords(removeWords(stderr, loaded, removeList)), nil
+}
+
+// wordleWords returns the words that can be played in the original Wordle:
+// five letters, all of them English.
+func wordleWords(words []string) []string {
+	return Lists{Answers: words}.OfLength(wordle.WordLength).InAlphabet(wordle.English).Answers
+}
+
+// CreateLists loads the answer list and the guess list, dropping the words in
+// the remove list from both. If guesses is empty, the answers are the only
+// allowed guesses.
+func CreateLists(stderr io.Writer, answers, guesses, remove string) (Lists, error) {
+	return CreateListsFrom(stderr, FileSource(answers), FileSource(guesses), FileSource(remove))
+}
 
+// CreateListsFS is CreateLists for files in fsys.
+func CreateListsFS(stderr io.Writer, fsys fs.FS, answers, guesses, remove string) (Lists, error) {
+	return CreateListsFrom(stderr, FSSource(fsys, answers), FSSource(fsys, guesses), FSSource(fsys, remove))
+}
+
+// CreateListsFrom is CreateLists for sources. guesses and remove may be the
+// zero Source.
+func CreateListsFrom(stderr io.Writer, answers, guesses, remove Source) (Lists, error) {
+	answerWords, err := loadDictionary(stderr, answers)
+	if err != nil {
+		return Lists{}, err
+	}
+	var guessWords map[string]bool
+	if !guesses.IsZero() {
+		guessWords, err = loadDictionary(stderr, guesses)
+		if err != nil {
+			return Lists{}, err
+		}
+	}
+	removeList, err := loadWords(stderr, remove)
+	if err != nil {
+		return Lists{}, err
+	}
+	return createLists(stderr, answerWords, guessWords, removeList), nil
+}
+
+// CreateListsFromReaders is CreateLists for words read from readers. guesses
+// and remove may be nil.
+func CreateListsFromReaders(stderr io.Writer, answers, guesses, remove io.Reader) (Lists, error) {
+	answerWords, err := readDictionary(stderr, answers, "answers")
+	if err != nil {
+		return Lists{}, err
+	}
+	var guessWords map[string]bool
+	if guesses != nil {
+		guessWords, err = readDictionary(stderr, guesses, "guesses")
+		if err != nil {
+			return Lists{}, err
+		}
+	}
+	removeList, err := readRemoveList(stderr, remove)
+	if err != nil {
+		return Lists{}, err
+	}
+	return createLists(stderr, answerWords, guessWords, removeList), nil
+}
+
+// createLists drops the words in removeList from answers and guesses. guesses
+// is nil if there is no guess list.
+func createLists(stderr io.Writer, answers, guesses map[string]bool, removeList []string) Lists {
+	answerWords := removeWords(stderr, answers, removeList)
+	if guesses == nil {
+		_, _ = fmt.Fprintf(stderr, "No guess list. Only answers can be guessed.\n")
+		return Lists{Answers: answerWords, Guesses: answerWords}
+	}
+
+	// Every answer must be a valid guess
+	set := make(map[string]bool, len(guesses)+len(answerWords))
+	for word := range guesses {
+		set[word] = true
+	}
+	for _, word := range removeList {
+		delete(set, word)
+	}
+	for _, word := range answerWords {
+		set[word] = true
+	}
+	_, _ = fmt.Fprintf(stderr, "%d answers and %d allowed guesses\n", len(answerWords), len(set))
+
+	return Lists{Answers: answerWords, Guesses: sortedWords(set)}
+}
+
+// removeWords deletes the words in removeList from loaded and returns the
+// rest, sorted.
+func removeWords(stderr io.Writer, loaded map[string]bool, removeList []string) []string {
 	for _, word := range removeList {
 		delete(loaded, word)
 	}
 	_, _ = fmt.Fprintf(stderr, "Loaded has %d words after removing the remove list\n", len(loaded))
 
-	// Convert to a sorted slice
+	return sortedWords(loaded)
+}
+
+func sortedWords(set map[string]bool) []string {
 	var words []string
-	for k := range loaded {
+	for k := range set {
 		words = append(words, k)
 	}
 	slices.Sort(words)
-
-	return words, nil
+	return words
 }
 
-func loadWords(stderr io.Writer, path string) ([]string, error) {
-	if path == "" {
+func loadWords(stderr io.Writer, src Source) ([]string, error) {
+	if src.IsZero() {
 		_, _ = fmt.Fprintf(stderr, "No remove list\n")
 		return nil, nil
 	}
-	file, err := os.Open(path)
+	file, err := src.open()
 	if err != nil {
 		return nil, err
 	}
@@ -50,23 +264,11 @@ func loadWords(stderr io.Writer, path string) ([]string, error) {
 		_ = file.Close()
 	}()
 
-	var words []string
-	scanFunc := func(s string) error {
-		words = append(words, strings.TrimSpace(s))
-		return nil
-	}
-	err = scan.Scan(file, scanFunc)
-	if err != nil {
-		return nil, err
-	}
-
-	_, _ = fmt.Fprintf(stderr, "Loaded %d words from %s\n", len(words), path)
-
-	return words, nil
+	return readWords(stderr, file, src.String())
 }
 
-func loadDictionary(stderr io.Writer, path string) (map[string]bool, error) {
-	file, err := os.Open(path)
+func loadDictionary(stderr io.Writer, src Source) (map[string]bool, error) {
+	file, err := src.open()
 	if err != nil {
 		return nil, err
 	}
@@ -74,51 +276,70 @@ func loadDictionary(stderr io.Writer, path string) (map[string]bool, error) {
 		_ = file.Close()
 	}()
 
+	return readDictionary(stderr, file, src.String())
+}
+
+// readRemoveList reads the remove list from r, which may be nil.
+func readRemoveList(stderr io.Writer, r io.Reader) ([]string, error) {
+	if r == nil {
+		_, _ = fmt.Fprintf(stderr, "No remove list\n")
+		return nil, nil
+	}
+	return readWords(stderr, r, "remove list")
+}
+
+// readWords reads every line of r as a word. name describes r for messages.
+func readWords(stderr io.Writer, r io.Reader, name string) ([]string, error) {
+	var words []string
+	scanFunc := func(word, _ string) error {
+		words = append(words, word)
+		return nil
+	}
+	if err := scanWords(r, name, scanFunc); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	_, _ = fmt.Fprintf(stderr, "Loaded %d words from %s\n", len(words), name)
+
+	return words, nil
+}
+
+// readDictionary reads the words of r that can be played. name describes r
+// for messages.
+func readDictionary(stderr io.Writer, r io.Reader, name string) (map[string]bool, error) {
 	set := make(map[string]bool)
 
-	scanFunc := func(s string) error {
-		if keepWord(s) {
-			set[s] = true
+	scanFunc := func(word, _ string) error {
+		if keepWord(word) {
+			set[word] = true
 		}
 		return nil
 	}
-	err = scan.Scan(file, scanFunc)
-	if err != nil {
-		return nil, err
+	if err := scanWords(r, name, scanFunc); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
 	}
 
-	_, _ = fmt.Fprintf(stderr, "Loaded %d words from %s\n", len(set), path)
+	_, _ = fmt.Fprintf(stderr, "Loaded %d words from %s\n", len(set), name)
 
 	return set, nil
 }
 
 // KeepWord will use built-in logic to determine if a word should be added to our working dictionary.
+// Words in any alphabet are kept; see Lists.InAlphabet.
 func keepWord(word string) bool {
-	if !isASCII(word) {
-		return false
-	}
 	if !isLetter(word) {
 		return false
 	}
-	if len(word) != 5 {
+	if wordle.CheckLength(wordle.Len(word)) != nil {
 		return false
 	}
-	if startsWithCapitalAscii(word) {
+	if startsWithCapital(word) {
 		return false
 	}
 
 	return true
 }
 
-func isASCII(s string) bool {
-	for _, r := range s {
-		if r > unicode.MaxASCII {
-			return false
-		}
-	}
-	return true
-}
-
 func isLetter(s string) bool {
 	for _, r := range s {
 		if !unicode.IsLetter(r) {
@@ -128,9 +349,7 @@ func isLetter(s string) bool {
 	return true
 }
 
-func startsWithCapitalAscii(s string) bool {
-	if len(s) == 0 {
-		return false
-	}
-	return s[0] >= 'A' && s[0] <= 'Z'
+func startsWithCapital(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
 }
@@ -0,0 +1,199 @@
+package dictionary_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"wordle/dictionary"
+	"wordle/wordle"
+)
+
+var files = fstest.MapFS{
+	"answers": {Data: []byte("crane\nguide\nsplit\nAbbey\nmuchtoolong\nclxvi\n")},
+	"guesses": {Data: []byte("aahed\nzymic\nclxvi\n")},
+	"remove":  {Data: []byte("clxvi\n")},
+}
+
+func TestCreateListsFromReaders(t *testing.T) {
+	lists, err := dictionary.CreateListsFromReaders(io.Discard,
+		strings.NewReader("crane\nguide\nsplit\nAbbey\nmuchtoolong\nclxvi\n"),
+		strings.NewReader("aahed\nzymic\nclxvi\n"),
+		strings.NewReader("clxvi\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := dictionary.Lists{
+		Answers: []string{"crane", "guide", "split"},
+		Guesses: []string{"aahed", "crane", "guide", "split", "zymic"},
+	}
+	if !reflect.DeepEqual(lists, want) {
+		t.Errorf("CreateListsFromReaders() = %v, want %v", lists, want)
+	}
+}
+
+func TestCreateListsFS(t *testing.T) {
+	lists, err := dictionary.CreateListsFS(io.Discard, files, "answers", "guesses", "remove")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := dictionary.Lists{
+		Answers: []string{"crane", "guide", "split"},
+		Guesses: []string{"aahed", "crane", "guide", "split", "zymic"},
+	}
+	if !reflect.DeepEqual(lists, want) {
+		t.Errorf("CreateListsFS() = %v, want %v", lists, want)
+	}
+
+	// Without a guess list the answers are the guesses
+	lists, err = dictionary.CreateListsFS(io.Discard, files, "answers", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = dictionary.Lists{
+		Answers: []string{"clxvi", "crane", "guide", "split"},
+		Guesses: []string{"clxvi", "crane", "guide", "split"},
+	}
+	if !reflect.DeepEqual(lists, want) {
+		t.Errorf("CreateListsFS() without guesses = %v, want %v", lists, want)
+	}
+
+	if _, err := dictionary.CreateListsFS(io.Discard, files, "missing", "", ""); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("CreateListsFS() of a missing file error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestOfLength(t *testing.T) {
+	lists, err := dictionary.CreateListsFromReaders(io.Discard,
+		strings.NewReader("crane\nlingo\nword\nbanana\nxy\n"),
+		strings.NewReader("aahs\ncrane\nzymic\n"),
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[int]dictionary.Lists{
+		4: {Answers: []string{"word"}, Guesses: []string{"aahs", "word"}, Length: 4},
+		5: {Answers: []string{"crane", "lingo"}, Guesses: []string{"crane", "lingo", "zymic"}, Length: 5},
+		6: {Answers: []string{"banana"}, Guesses: []string{"banana"}, Length: 6},
+		7: {Length: 7},
+	}
+	for n, want := range tests {
+		if got := lists.OfLength(n); !reflect.DeepEqual(got, want) {
+			t.Errorf("OfLength(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestInAlphabet(t *testing.T) {
+	lists, err := dictionary.CreateListsFromReaders(io.Discard,
+		strings.NewReader("está\nesta\nñandú\ncrane\nÁrbol\n"),
+		strings.NewReader("straße\nárbol\n"),
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lists.Frequencies = wordle.Frequencies{"está": 3, "esta": 1}
+
+	// Capitalized words are names, so Árbol isn't kept
+	if want := []string{"crane", "esta", "está", "ñandú"}; !reflect.DeepEqual(lists.Answers, want) {
+		t.Errorf("Answers = %v, want %v", lists.Answers, want)
+	}
+
+	folding := wordle.Spanish
+	folding.Fold = true
+	tests := []struct {
+		alphabet wordle.Alphabet
+		want     dictionary.Lists
+	}{
+		{wordle.English, dictionary.Lists{
+			Answers:     []string{"crane", "esta"},
+			Guesses:     []string{"crane", "esta"},
+			Frequencies: wordle.Frequencies{"esta": 1},
+			Alphabet:    wordle.English,
+		}},
+		{folding, dictionary.Lists{
+			Answers:     []string{"crane", "esta", "ñandu"},
+			Guesses:     []string{"arbol", "crane", "esta", "ñandu"},
+			Frequencies: wordle.Frequencies{"esta": 4},
+			Alphabet:    folding,
+		}},
+	}
+	for _, tt := range tests {
+		if got := lists.InAlphabet(tt.alphabet); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("InAlphabet(%s) = %v, want %v", tt.alphabet, got, tt.want)
+		}
+	}
+}
+
+func TestCreateFromReader(t *testing.T) {
+	words, err := dictionary.CreateFromReader(io.Discard, strings.NewReader("split\ncrane\nclxvi\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"clxvi", "crane", "split"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("CreateFromReader() = %v, want %v", words, want)
+	}
+
+	// Unlike the Lists, only five letter English words are kept
+	words, err = dictionary.CreateFromReader(io.Discard, strings.NewReader("crane\ncat\nbanana\nárbol\nsplit\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"crane", "split"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("CreateFromReader() = %v, want %v", words, want)
+	}
+}
+
+func TestCreateFromEmbedded(t *testing.T) {
+	words, err := dictionary.CreateFrom(io.Discard,
+		dictionary.EmbeddedSource(dictionary.DefaultAnswers),
+		dictionary.EmbeddedSource(dictionary.DefaultRemove),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) == 0 {
+		t.Fatal("CreateFrom() of the built-in list is empty")
+	}
+	for _, w := range words {
+		if wordle.Len(w) != wordle.WordLength {
+			t.Fatalf("CreateFrom() of the built-in list has %q, want only five letter words", w)
+		}
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	lists, err := dictionary.CreateListsFrom(io.Discard,
+		dictionary.EmbeddedSource(dictionary.DefaultAnswers),
+		dictionary.EmbeddedSource(dictionary.DefaultGuesses),
+		dictionary.EmbeddedSource(dictionary.DefaultRemove),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists.Answers) == 0 || len(lists.Guesses) < len(lists.Answers) {
+		t.Errorf("built-in lists have %d answers and %d guesses", len(lists.Answers), len(lists.Guesses))
+	}
+}
+
+func TestSourceString(t *testing.T) {
+	tests := map[dictionary.Source]string{
+		{}:                             "(none)",
+		dictionary.FileSource("a/b"):   "a/b",
+		dictionary.EmbeddedSource("x"): "built-in x",
+	}
+	for src, want := range tests {
+		if got := src.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+	if got := dictionary.FSSource(files, "answers").String(); got != "answers" {
+		t.Errorf("FSSource String() = %q, want %q", got, "answers")
+	}
+}
@@ -0,0 +1,180 @@
+package dictionary
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"wordle/scan"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// format is how the words are laid out in a word file. A file's format comes
+// from its extension, ignoring a .gz or .zst extension, or if it has none that
+// we know of, from its first line.
+type format int
+
+const (
+	formatUnknown format = iota
+	// One word per line
+	formatPlain
+	// A word, a tab, then its frequency. An optional header line is skipped.
+	formatTSV
+	// A word, a comma, then its frequency. An optional header line is skipped.
+	formatCSV
+	// A Hunspell dictionary: a line with the word count, then one word per
+	// line followed by /affix-flags and morphological fields, which are
+	// ignored.
+	formatHunspell
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// scanWords calls fn with the word on each line of r and its frequency, which
+// is empty if the format has none. r may be compressed with gzip or zstd. name
+// is used to tell which format r is in.
+func scanWords(r io.Reader, name string, fn func(word, freq string) error) error {
+	r, closeFn, err := decompress(r)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	f := formatOf(name)
+	first := true
+	return scan.Scan(r, func(line string) error {
+		if first {
+			first = false
+			if f == formatUnknown {
+				f = sniffFormat(line)
+			}
+			if isHeader(f, line) {
+				return nil
+			}
+		}
+		word, freq := entryOf(f, line)
+		return fn(word, freq)
+	})
+}
+
+// decompress returns a reader of the uncompressed contents of r, telling
+// whether r is compressed from its first bytes, and a function to call when
+// done reading.
+func decompress(r io.Reader) (io.Reader, func(), error) {
+	br := bufio.NewReader(r)
+	// A short file is fine; it just isn't compressed
+	magic, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid gzip data: %w", err)
+		}
+		return zr, func() { _ = zr.Close() }, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid zstd data: %w", err)
+		}
+		return zr, zr.Close, nil
+	}
+	return br, func() {}, nil
+}
+
+// formatOf returns the format of a file called name from its extension.
+func formatOf(name string) format {
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".gz", ".zst", ".zstd":
+		return formatOf(strings.TrimSuffix(name, filepath.Ext(name)))
+	case ".txt":
+		return formatPlain
+	case ".tsv":
+		return formatTSV
+	case ".csv":
+		return formatCSV
+	case ".dic":
+		return formatHunspell
+	}
+	return formatUnknown
+}
+
+// sniffFormat guesses the format of a file from its first line.
+func sniffFormat(line string) format {
+	line = strings.TrimSpace(line)
+	switch {
+	case isNumber(line):
+		return formatHunspell
+	case strings.Contains(line, "\t"):
+		return formatTSV
+	case strings.Contains(line, ","):
+		return formatCSV
+	}
+	return formatPlain
+}
+
+// isHeader reports whether the first line of a file in format f isn't a word.
+func isHeader(f format, line string) bool {
+	switch f {
+	case formatHunspell:
+		return isNumber(strings.TrimSpace(line))
+	case formatTSV, formatCSV:
+		// A header names the columns instead of giving a frequency
+		fields := splitFields(f, line)
+		return len(fields) > 1 && !isNumber(fields[1])
+	}
+	return false
+}
+
+// entryOf returns the word on a line of a file in format f and its frequency,
+// if the format has one.
+func entryOf(f format, line string) (word, freq string) {
+	switch f {
+	case formatTSV, formatCSV:
+		fields := splitFields(f, line)
+		if len(fields) > 1 {
+			return fields[0], fields[1]
+		}
+		return fields[0], ""
+	case formatHunspell:
+		word, _, _ := strings.Cut(strings.TrimSpace(line), "/")
+		if i := strings.IndexAny(word, " \t"); i >= 0 {
+			word = word[:i]
+		}
+		return word, ""
+	}
+	return strings.TrimSpace(line), ""
+}
+
+// splitFields splits a line of a TSV or CSV file into its trimmed fields,
+// dropping any quotes around them.
+func splitFields(f format, line string) []string {
+	sep := "\t"
+	if f == formatCSV {
+		sep = ","
+	}
+	fields := strings.Split(line, sep)
+	for i, field := range fields {
+		fields[i] = strings.Trim(strings.TrimSpace(field), `"`)
+	}
+	return fields
+}
+
+// isNumber reports whether s is a count or a frequency. Words like "inf"
+// aren't numbers here.
+func isNumber(s string) bool {
+	digits := strings.TrimLeft(s, "+-")
+	if digits == "" || (digits[0] < '0' || digits[0] > '9') && digits[0] != '.' {
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
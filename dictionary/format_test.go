@@ -0,0 +1,87 @@
+package dictionary_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"wordle/dictionary"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstded(t *testing.T, s string) []byte {
+	t.Helper()
+	zw, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = zw.Close()
+	}()
+	return zw.EncodeAll([]byte(s), nil)
+}
+
+func TestFormats(t *testing.T) {
+	want := []string{"crane", "guide", "split"}
+	tests := map[string][]byte{
+		"plain":             []byte("crane\nguide\nsplit\n"),
+		"plain.gz":          gzipped(t, "crane\nguide\nsplit\n"),
+		"plain.zst":         zstded(t, "crane\nguide\nsplit\n"),
+		"freq.tsv":          []byte("word\tfrequency\ncrane\t120\nguide\t80.5\nsplit\t3e-6\n"),
+		"freq.tsv.gz":       gzipped(t, "crane\t120\nguide\t80\nsplit\t3\n"),
+		"freq.csv":          []byte("word,count\n\"crane\",120\nguide, 80\nsplit,3\n"),
+		"sniffed-tsv":       []byte("crane\t120\nguide\t80\nsplit\t3\n"),
+		"sniffed-csv":       []byte("crane,120\nguide,80\nsplit,3\n"),
+		"en_US.dic":         []byte("4\ncrane/SMDG\nguide/MS po:noun\nsplit\nLondon/M\n"),
+		"en_US.dic.zst":     zstded(t, "3\ncrane/SMDG\nguide/MS\nsplit\n"),
+		"sniffed-hunspell":  []byte("3\ncrane/SMDG\nguide/MS\nsplit\n"),
+		"gzip-without-name": gzipped(t, "3\ncrane/SMDG\nguide/MS\nsplit\n"),
+	}
+	for name, data := range tests {
+		fsys := fstest.MapFS{name: {Data: data}}
+		lists, err := dictionary.CreateListsFS(io.Discard, fsys, name, "", "")
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(lists.Answers, want) {
+			t.Errorf("%s: answers = %v, want %v", name, lists.Answers, want)
+		}
+	}
+}
+
+func TestCompressedRemoveList(t *testing.T) {
+	words, err := dictionary.CreateFromReader(io.Discard,
+		bytes.NewReader(gzipped(t, "crane\nguide\nsplit\n")),
+		bytes.NewReader(zstded(t, "guide\n")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"crane", "split"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("CreateFromReader() = %v, want %v", words, want)
+	}
+}
+
+func TestInvalidGzip(t *testing.T) {
+	fsys := fstest.MapFS{"words.gz": {Data: []byte{0x1f, 0x8b, 0x00}}}
+	if _, err := dictionary.CreateListsFS(io.Discard, fsys, "words.gz", "", ""); err == nil {
+		t.Error("CreateListsFS() of truncated gzip error = nil, want error")
+	}
+}
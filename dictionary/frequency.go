@@ -0,0 +1,62 @@
+package dictionary
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"wordle/wordle"
+)
+
+// LoadFrequencies reads a word-frequency table from src. The table is a TSV
+// or CSV file of words and how often they're used, in any unit, or a list of
+// words in order, most common first, in any of the formats word lists can be
+// in. Words that can't be played are skipped.
+func LoadFrequencies(stderr io.Writer, src Source) (wordle.Frequencies, error) {
+	file, err := src.open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return readFrequencies(stderr, file, src.String())
+}
+
+// ReadFrequencies is LoadFrequencies for a table read from r.
+func ReadFrequencies(stderr io.Writer, r io.Reader) (wordle.Frequencies, error) {
+	return readFrequencies(stderr, r, "frequency table")
+}
+
+func readFrequencies(stderr io.Writer, r io.Reader, name string) (wordle.Frequencies, error) {
+	freqs := make(wordle.Frequencies)
+	rank := 0
+	scanFunc := func(word, freq string) error {
+		rank++
+		if !keepWord(word) {
+			return nil
+		}
+		if freq == "" {
+			// A list in order of use; Zipf's law says the frequency of the
+			// nth most common word is about 1/n
+			if _, ok := freqs[word]; !ok {
+				freqs[word] = 1 / float64(rank)
+			}
+			return nil
+		}
+		f, err := strconv.ParseFloat(freq, 64)
+		if err != nil || f < 0 {
+			return fmt.Errorf("invalid frequency %q for %q", freq, word)
+		}
+		// A word listed more than once is as common as all of them together
+		freqs[word] += f
+		return nil
+	}
+	if err := scanWords(r, name, scanFunc); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	_, _ = fmt.Fprintf(stderr, "Loaded %d word frequencies from %s\n", len(freqs), name)
+
+	return freqs, nil
+}
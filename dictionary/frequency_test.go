@@ -0,0 +1,40 @@
+package dictionary_test
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"wordle/dictionary"
+	"wordle/wordle"
+)
+
+func TestLoadFrequencies(t *testing.T) {
+	fsys := fstest.MapFS{
+		"freq.tsv": {Data: []byte("word\tcount\ncrane\t120\nguide\t80\nGuide\t5\nmuchtoolong\t9\nguide\t20\n")},
+		"ranked":   {Data: []byte("crane\nthe\nguide\ncrane\n")},
+	}
+	tests := map[string]wordle.Frequencies{
+		"freq.tsv": {"crane": 120, "guide": 100},
+		"ranked":   {"crane": 1, "the": 0.5, "guide": 1.0 / 3},
+	}
+	for name, want := range tests {
+		got, err := dictionary.LoadFrequencies(io.Discard, dictionary.FSSource(fsys, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: LoadFrequencies() = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestReadFrequenciesErrors(t *testing.T) {
+	for _, table := range []string{"crane\t12\nguide\tlots\n", "crane,-1\n"} {
+		if _, err := dictionary.ReadFrequencies(io.Discard, strings.NewReader(table)); err == nil {
+			t.Errorf("ReadFrequencies(%q) error = nil, want error", table)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package dictionary
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historyDate is how dates are written in a history file.
+const historyDate = "2006-01-02"
+
+// PastAnswer is an answer the NYT has already used.
+type PastAnswer struct {
+	Date   time.Time
+	Number int
+	Word   string
+}
+
+// History is the past official answers, oldest first.
+type History []PastAnswer
+
+// LoadHistory reads the past answers from src, a CSV file with the columns
+// date (as 2006-01-02), puzzle number and word. A header line is skipped.
+// Like word lists, the file may be compressed.
+func LoadHistory(stderr io.Writer, src Source) (History, error) {
+	file, err := src.open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return readHistory(stderr, file, src.String())
+}
+
+// ReadHistory is LoadHistory for a history read from r.
+func ReadHistory(stderr io.Writer, r io.Reader) (History, error) {
+	return readHistory(stderr, r, "history")
+}
+
+func readHistory(stderr io.Writer, r io.Reader, name string) (History, error) {
+	r, closeFn, err := decompress(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	defer closeFn()
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	var history History
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		answer, err := parsePastAnswer(record)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			if line == 1 {
+				continue // a header naming the columns
+			}
+			return nil, fmt.Errorf("%s line %d: %w", name, line, err)
+		}
+		history = append(history, answer)
+	}
+	slices.SortStableFunc(history, func(a, b PastAnswer) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	_, _ = fmt.Fprintf(stderr, "Loaded %d past answers from %s\n", len(history), name)
+
+	return history, nil
+}
+
+func parsePastAnswer(record []string) (PastAnswer, error) {
+	date, err := time.Parse(historyDate, strings.TrimSpace(record[0]))
+	if err != nil {
+		return PastAnswer{}, fmt.Errorf("invalid date %q", record[0])
+	}
+	number, err := strconv.Atoi(strings.TrimSpace(record[1]))
+	if err != nil {
+		return PastAnswer{}, fmt.Errorf("invalid puzzle number %q", record[1])
+	}
+	word := strings.ToLower(strings.TrimSpace(record[2]))
+	if word == "" {
+		return PastAnswer{}, fmt.Errorf("no word")
+	}
+	return PastAnswer{Date: date, Number: number, Word: word}, nil
+}
+
+// UsedBefore returns the set of words that were the answer before day. Only
+// the date of day counts, so today's answer is never in the set even if the
+// history already has it.
+func (h History) UsedBefore(day time.Time) map[string]bool {
+	start := dateOf(day)
+	used := make(map[string]bool, len(h))
+	for _, a := range h {
+		if dateOf(a.Date).Before(start) {
+			used[a.Word] = true
+		}
+	}
+	return used
+}
+
+// ExcludeUsed returns the words that weren't the answer before day, keeping
+// their order.
+func (h History) ExcludeUsed(words []string, day time.Time) []string {
+	used := h.UsedBefore(day)
+	if len(used) == 0 {
+		return words
+	}
+	kept := make([]string, 0, len(words))
+	for _, w := range words {
+		if !used[w] {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+// dateOf returns midnight UTC on t's date in its own location, so dates can be
+// compared whatever the location.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
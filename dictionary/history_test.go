@@ -0,0 +1,64 @@
+package dictionary_test
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+	"wordle/dictionary"
+)
+
+const history = `date,number,word
+2021-06-20,1,REBUT
+2021-06-19,0,cigar
+2021-06-21,2,sissy
+`
+
+func TestLoadHistory(t *testing.T) {
+	fsys := fstest.MapFS{"answers.csv.gz": {Data: gzipped(t, history)}}
+	h, err := dictionary.LoadHistory(io.Discard, dictionary.FSSource(fsys, "answers.csv.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var words []string
+	for _, a := range h {
+		words = append(words, a.Word)
+	}
+	if want := []string{"cigar", "rebut", "sissy"}; !slices.Equal(words, want) {
+		t.Errorf("LoadHistory() words = %v, want %v, oldest first", words, want)
+	}
+	if h[1].Number != 1 || !h[1].Date.Equal(time.Date(2021, 6, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("LoadHistory() second answer = %+v", h[1])
+	}
+}
+
+func TestReadHistoryErrors(t *testing.T) {
+	for _, text := range []string{
+		"2021-06-19,0,cigar\n2021-13-01,1,rebut\n",
+		"2021-06-19,0,cigar\n2021-06-20,one,rebut\n",
+		"2021-06-19,0,cigar\n2021-06-20,1\n",
+	} {
+		if _, err := dictionary.ReadHistory(io.Discard, strings.NewReader(text)); err == nil {
+			t.Errorf("ReadHistory(%q) error = nil, want error", text)
+		}
+	}
+}
+
+func TestExcludeUsed(t *testing.T) {
+	h, err := dictionary.ReadHistory(io.Discard, strings.NewReader(history))
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := []string{"cigar", "crane", "rebut", "sissy"}
+
+	// The answer on the day itself is still possible
+	day := time.Date(2021, 6, 20, 23, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	if got, want := h.ExcludeUsed(words, day), []string{"crane", "rebut", "sissy"}; !slices.Equal(got, want) {
+		t.Errorf("ExcludeUsed() = %v, want %v", got, want)
+	}
+	if got := dictionary.History(nil).ExcludeUsed(words, day); !slices.Equal(got, words) {
+		t.Errorf("ExcludeUsed() without a history = %v, want %v", got, words)
+	}
+}
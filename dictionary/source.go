@@ -0,0 +1,101 @@
+package dictionary
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// The word lists built into the binaries, used when no file is given.
+const (
+	DefaultAnswers = "american-english"
+	DefaultGuesses = "nytimes"
+	DefaultRemove  = "words-to-remove"
+)
+
+//go:embed data
+var data embed.FS
+
+var embedded = func() fs.FS {
+	sub, err := fs.Sub(data, "data")
+	if err != nil {
+		panic(err) // the directory is embedded, so this can't happen
+	}
+	return sub
+}()
+
+// Embedded returns the file system holding the built-in word lists.
+func Embedded() fs.FS {
+	return embedded
+}
+
+// Source is a word file: a name in a file system. Path is where the file is
+// on disk, or empty if it isn't on disk, as for the built-in lists. The zero
+// Source means no file.
+type Source struct {
+	FS   fs.FS
+	Name string
+	Path string
+}
+
+// FileSource returns the source for the file at path. An empty path returns
+// the zero Source.
+func FileSource(path string) Source {
+	if path == "" {
+		return Source{}
+	}
+	return Source{FS: os.DirFS(filepath.Dir(path)), Name: filepath.Base(path), Path: path}
+}
+
+// FSSource returns the source for the file name in fsys, such as an embed.FS
+// or a fstest.MapFS. An empty name returns the zero Source.
+func FSSource(fsys fs.FS, name string) Source {
+	if name == "" {
+		return Source{}
+	}
+	return Source{FS: fsys, Name: name}
+}
+
+// EmbeddedSource returns the source for one of the built-in word lists.
+func EmbeddedSource(name string) Source {
+	return FSSource(embedded, name)
+}
+
+// FileOrEmbedded returns the source for the file at path, or the built-in list
+// called name if path is empty.
+func FileOrEmbedded(path, name string) Source {
+	if path == "" {
+		return EmbeddedSource(name)
+	}
+	return FileSource(path)
+}
+
+// IsZero reports whether s is the zero Source.
+func (s Source) IsZero() bool {
+	return s.FS == nil
+}
+
+// String describes the source for messages.
+func (s Source) String() string {
+	switch {
+	case s.IsZero():
+		return "(none)"
+	case s.Path != "":
+		return s.Path
+	case s.FS == embedded:
+		return "built-in " + s.Name
+	}
+	return s.Name
+}
+
+// open opens the source's file. Errors name the file's path when it has one.
+func (s Source) open() (fs.File, error) {
+	f, err := s.FS.Open(s.Name)
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) && s.Path != "" {
+		pathErr.Path = s.Path
+	}
+	return f, err
+}
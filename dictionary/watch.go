@@ -0,0 +1,66 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"time"
+)
+
+// Watch checks the word list's files every interval and reloads them when
+// one has been modified, until ctx is done. A failed reload is reported and
+// the current words are kept until the files change again.
+func (wl *WordList) Watch(ctx context.Context, interval time.Duration) {
+	wl.mu.RLock()
+	last := wl.modTimes
+	wl.mu.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := wl.fileModTimes()
+		if maps.Equal(current, last) {
+			continue
+		}
+		last = current
+
+		if err := wl.Reload(); err != nil {
+			_, _ = fmt.Fprintf(wl.stderr, "Keeping the current dictionary: %s\n", err)
+		}
+	}
+}
+
+// fileModTimes returns when each of the word list's files was last modified. A
+// file that can't be read has the zero time, so it counts as modified when it
+// comes back. The built-in lists never change.
+func (wl *WordList) fileModTimes() map[string]time.Time {
+	wl.mu.RLock()
+	sources := []Source{wl.answers, wl.guesses, wl.remove, wl.freqs, wl.history}
+	wl.mu.RUnlock()
+
+	times := make(map[string]time.Time)
+	for _, src := range sources {
+		if !src.IsZero() {
+			times[src.String()] = modTime(src)
+		}
+	}
+	return times
+}
+
+// modTime returns when src was last modified, or the zero time if it can't be
+// read.
+func modTime(src Source) time.Time {
+	info, err := fs.Stat(src.FS, src.Name)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -3,24 +3,49 @@ package dictionary
 import (
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"sync"
+	"time"
+	"wordle/wordle"
 )
 
 // WordList manages the in-memory dictionary words.
 type WordList struct {
-	words      []string
-	dictPath   string
-	removePath string
-	stderr     io.Writer
-	mu         sync.RWMutex
+	all      Lists         // words of every length and alphabet
+	lists    Lists         // the words of length letters in alphabet
+	index    *wordle.Index // of lists.Answers
+	length   int
+	alphabet wordle.Alphabet
+	answers  Source
+	guesses  Source
+	remove   Source
+	freqs    Source
+	history  Source
+	stderr   io.Writer
+	mu       sync.RWMutex
+	reloadMu sync.Mutex // serializes reloads
+	editMu   sync.Mutex // serializes changes to the remove list
+	loadedAt time.Time
+	modTimes map[string]time.Time // of the files when they were loaded
 }
 
-// NewWordList creates a new managed word list
-func NewWordList(stderr io.Writer, dictPath, removePath string) (*WordList, error) {
+// NewWordList creates a new managed word list. guessesPath may be empty, in
+// which case only the answers can be guessed.
+func NewWordList(stderr io.Writer, answersPath, guessesPath, removePath string) (*WordList, error) {
+	return NewWordListFrom(stderr, FileSource(answersPath), FileSource(guessesPath), FileSource(removePath))
+}
+
+// NewWordListFrom creates a new managed word list from sources. guesses and
+// remove may be the zero Source.
+func NewWordListFrom(stderr io.Writer, answers, guesses, remove Source) (*WordList, error) {
 	wl := &WordList{
-		dictPath:   dictPath,
-		removePath: removePath,
-		stderr:     stderr,
+		answers:  answers,
+		guesses:  guesses,
+		remove:   remove,
+		length:   wordle.WordLength,
+		alphabet: wordle.English,
+		stderr:   stderr,
 	}
 
 	if err := wl.Reload(); err != nil {
@@ -30,33 +55,226 @@ func NewWordList(stderr io.Writer, dictPath, removePath string) (*WordList, erro
 	return wl, nil
 }
 
-// Reload refreshes the word list from the configured files.
+// Reload refreshes the word list from the configured files. If the files
+// can't be loaded the current words are kept.
 func (wl *WordList) Reload() error {
-	wl.mu.Lock()
-	defer wl.mu.Unlock()
+	wl.reloadMu.Lock()
+	defer wl.reloadMu.Unlock()
 
-	_, _ = fmt.Fprintf(wl.stderr, "Reloading dictionary from %s\n", wl.dictPath)
+	_, _ = fmt.Fprintf(wl.stderr, "Reloading dictionary from %s\n", wl.answers)
 
-	words, err := Create(wl.stderr, wl.dictPath, wl.removePath)
+	// Check the files before reading them, so a change made while they're
+	// being read is picked up by the next Watch
+	modTimes := wl.fileModTimes()
+	lists, err := CreateListsFrom(wl.stderr, wl.answers, wl.guesses, wl.remove)
 	if err != nil {
 		return fmt.Errorf("failed to reload dictionary: %w", err)
 	}
+	if !wl.freqs.IsZero() {
+		lists.Frequencies, err = LoadFrequencies(wl.stderr, wl.freqs)
+		if err != nil {
+			return fmt.Errorf("failed to reload dictionary: %w", err)
+		}
+	}
+	if !wl.history.IsZero() {
+		lists.History, err = LoadHistory(wl.stderr, wl.history)
+		if err != nil {
+			return fmt.Errorf("failed to reload dictionary: %w", err)
+		}
+	}
 
-	wl.words = words
+	wl.mu.Lock()
+	old := wl.lists
+	wl.all = lists
+	lists = wl.playable(lists)
+	wl.setLists(lists)
+	wl.loadedAt = time.Now()
+	wl.modTimes = modTimes
+	wl.mu.Unlock()
 
-	_, _ = fmt.Fprintf(wl.stderr, "Dictionary reloaded: %d words available\n", len(wl.words))
+	_, _ = fmt.Fprintf(wl.stderr, "Dictionary reloaded: %d answers (%+d) and %d guesses (%+d) available\n",
+		len(lists.Answers), len(lists.Answers)-len(old.Answers),
+		len(lists.Guesses), len(lists.Guesses)-len(old.Guesses))
 
 	return nil
 }
 
-// Words returns a copy of the current word list (thread-safe)
-func (wl *WordList) Words() []string {
+// Answers returns a copy of the words that could be the answer (thread-safe)
+func (wl *WordList) Answers() []string {
 	wl.mu.RLock()
 	defer wl.mu.RUnlock()
 
 	// Return a copy to prevent external modification
-	result := make([]string, len(wl.words))
-	copy(result, wl.words)
+	result := make([]string, len(wl.lists.Answers))
+	copy(result, wl.lists.Answers)
 	return result
 }
 
+// Index returns the answers indexed for filtering by constraints
+// (thread-safe). It's rebuilt whenever the answers change.
+func (wl *WordList) Index() *wordle.Index {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return wl.index
+}
+
+// Guesses returns a copy of the words that may be guessed (thread-safe)
+func (wl *WordList) Guesses() []string {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+
+	// Return a copy to prevent external modification
+	result := make([]string, len(wl.lists.Guesses))
+	copy(result, wl.lists.Guesses)
+	return result
+}
+
+// Length returns how many letters the words have (thread-safe)
+func (wl *WordList) Length() int {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return wl.length
+}
+
+// ListsOfLength returns the words of n letters in the current alphabet,
+// whatever length is being played (thread-safe). They aren't indexed.
+func (wl *WordList) ListsOfLength(n int) Lists {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return wl.all.OfLength(n).InAlphabet(wl.alphabet)
+}
+
+// SetLength changes how many letters the words have. The words of every
+// length are kept, so it doesn't reload the files.
+func (wl *WordList) SetLength(n int) error {
+	if err := wordle.CheckLength(n); err != nil {
+		return err
+	}
+	wl.reloadMu.Lock()
+	defer wl.reloadMu.Unlock()
+
+	wl.mu.Lock()
+	wl.length = n
+	lists := wl.playable(wl.all)
+	wl.setLists(lists)
+	wl.mu.Unlock()
+
+	_, _ = fmt.Fprintf(wl.stderr, "Playing %d letter words: %d answers and %d guesses available\n",
+		n, len(lists.Answers), len(lists.Guesses))
+	return nil
+}
+
+// Alphabet returns the alphabet the words are in (thread-safe)
+func (wl *WordList) Alphabet() wordle.Alphabet {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return wl.alphabet
+}
+
+// SetAlphabet changes the alphabet the words are in. Like SetLength, it
+// doesn't reload the files.
+func (wl *WordList) SetAlphabet(a wordle.Alphabet) {
+	wl.reloadMu.Lock()
+	defer wl.reloadMu.Unlock()
+
+	wl.mu.Lock()
+	wl.alphabet = a
+	lists := wl.playable(wl.all)
+	wl.setLists(lists)
+	wl.mu.Unlock()
+
+	_, _ = fmt.Fprintf(wl.stderr, "Playing words in %s: %d answers and %d guesses available\n",
+		a, len(lists.Answers), len(lists.Guesses))
+}
+
+// playable returns the words in all that can be played with the current
+// alphabet and length. wl.mu must be held.
+func (wl *WordList) playable(all Lists) Lists {
+	return all.OfLength(wl.length).InAlphabet(wl.alphabet)
+}
+
+// setLists makes lists the playable words, indexing the answers if they
+// changed. wl.mu must be held.
+func (wl *WordList) setLists(lists Lists) {
+	if wl.index == nil || !slices.Equal(lists.Answers, wl.lists.Answers) {
+		wl.index = wordle.NewIndex(lists.Answers)
+	}
+	wl.lists = lists
+}
+
+// Frequencies returns how common the words are, or nil if no frequency table
+// is loaded (thread-safe). The table must not be modified.
+func (wl *WordList) Frequencies() wordle.Frequencies {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return wl.lists.Frequencies
+}
+
+// SetFrequencies loads the word-frequency table from src, which is then
+// reloaded along with the word files. The zero Source drops the table.
+func (wl *WordList) SetFrequencies(src Source) error {
+	wl.reloadMu.Lock()
+	defer wl.reloadMu.Unlock()
+
+	var freqs wordle.Frequencies
+	if !src.IsZero() {
+		var err error
+		freqs, err = LoadFrequencies(wl.stderr, src)
+		if err != nil {
+			return err
+		}
+	}
+
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	wl.swapModTime(wl.freqs, src)
+	wl.freqs = src
+	wl.all.Frequencies = freqs
+	wl.setLists(wl.playable(wl.all))
+	return nil
+}
+
+// History returns the past official answers, or nil if no history is loaded
+// (thread-safe). The history must not be modified.
+func (wl *WordList) History() History {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return wl.lists.History
+}
+
+// SetHistory loads the past official answers from src, which is then reloaded
+// along with the word files. The zero Source drops the history.
+func (wl *WordList) SetHistory(src Source) error {
+	wl.reloadMu.Lock()
+	defer wl.reloadMu.Unlock()
+
+	var history History
+	if !src.IsZero() {
+		var err error
+		history, err = LoadHistory(wl.stderr, src)
+		if err != nil {
+			return err
+		}
+	}
+
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	wl.swapModTime(wl.history, src)
+	wl.history = src
+	wl.all.History = history
+	wl.setLists(wl.playable(wl.all))
+	return nil
+}
+
+// swapModTime makes Watch check src instead of old. wl.mu must be held.
+func (wl *WordList) swapModTime(old, src Source) {
+	modTimes := maps.Clone(wl.modTimes)
+	if modTimes == nil {
+		modTimes = make(map[string]time.Time)
+	}
+	delete(modTimes, old.String())
+	if !src.IsZero() {
+		modTimes[src.String()] = modTime(src)
+	}
+	wl.modTimes = modTimes
+}
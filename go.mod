@@ -2,4 +2,8 @@ module wordle
 
 go 1.22
 
-require github.com/maragudk/gomponents v0.22.0
+require (
+	github.com/klauspost/compress v1.18.0
+	github.com/maragudk/gomponents v0.22.0
+	golang.org/x/text v0.22.0
+)
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"wordle/dictionary"
+)
+
+// AdminWordList is a word list that can be reloaded and edited
+type AdminWordList interface {
+	WordList
+	Reload() error
+	Stats() (dictionary.Stats, error)
+	RemoveWord(word string) error
+	AddWord(word string) error
+}
+
+// WordRequest is the body of POST /admin/remove-word and POST /admin/add-word
+type WordRequest struct {
+	Word string `json:"word"`
+}
+
+// StatsResponse is the result of the admin endpoints
+type StatsResponse struct {
+	Length            int       `json:"length"`
+	Answers           int       `json:"answers"`
+	Guesses           int       `json:"guesses"`
+	Removed           int       `json:"removed"`
+	AnswersSource     string    `json:"answers_source"`
+	GuessesSource     string    `json:"guesses_source"`
+	RemoveSource      string    `json:"remove_source"`
+	FrequenciesSource string    `json:"frequencies_source"`
+	HistorySource     string    `json:"history_source"`
+	LoadedAt          time.Time `json:"loaded_at"`
+}
+
+// RequireToken only lets requests through to next if they carry the token as
+// a bearer token in the Authorization header
+func RequireToken(logger *slog.Logger, token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			logger.Warn("Rejected admin request", "path", r.URL.Path, "remote", r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeAPIError(w, logger, &apiError{
+				status:  http.StatusUnauthorized,
+				code:    "unauthorized",
+				message: "a valid admin token is required",
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleAdminReload reloads the dictionary from its files
+func HandleAdminReload(logger *slog.Logger, wordList AdminWordList) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Info("Reloading dictionary from admin request")
+		if err := wordList.Reload(); err != nil {
+			writeAPIError(w, logger, &apiError{status: http.StatusInternalServerError, code: "reload_failed", message: err.Error()})
+			return
+		}
+		writeStats(w, logger, wordList)
+	}
+}
+
+// HandleAdminStats reports how many words are loaded and where from
+func HandleAdminStats(logger *slog.Logger, wordList AdminWordList) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeStats(w, logger, wordList)
+	}
+}
+
+// HandleAdminRemoveWord adds a word to the remove list
+func HandleAdminRemoveWord(logger *slog.Logger, wordList AdminWordList) http.HandlerFunc {
+	return handleWordEdit(logger, "Removing word", wordList, wordList.RemoveWord)
+}
+
+// HandleAdminAddWord takes a word off the remove list
+func HandleAdminAddWord(logger *slog.Logger, wordList AdminWordList) http.HandlerFunc {
+	return handleWordEdit(logger, "Restoring word", wordList, wordList.AddWord)
+}
+
+func handleWordEdit(logger *slog.Logger, msg string, wordList AdminWordList, edit func(string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req WordRequest
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeAPIError(w, logger, err)
+			return
+		}
+		word := strings.ToLower(strings.TrimSpace(req.Word))
+		if word == "" {
+			writeAPIError(w, logger, badRequest(codeInvalidRequest, "word is required"))
+			return
+		}
+
+		logger.Info(msg, "word", word)
+		if err := edit(word); err != nil {
+			var apiErr *apiError
+			switch {
+			case errors.Is(err, dictionary.ErrNotInDictionary):
+				apiErr = &apiError{status: http.StatusNotFound, code: "not_found", message: err.Error()}
+			case errors.Is(err, dictionary.ErrNoRemoveList):
+				apiErr = &apiError{status: http.StatusConflict, code: "no_remove_list", message: err.Error()}
+			default:
+				apiErr = &apiError{status: http.StatusInternalServerError, code: codeInternal, message: err.Error()}
+			}
+			writeAPIError(w, logger, apiErr)
+			return
+		}
+		writeStats(w, logger, wordList)
+	}
+}
+
+func writeStats(w http.ResponseWriter, logger *slog.Logger, wordList AdminWordList) {
+	stats, err := wordList.Stats()
+	if err != nil {
+		writeAPIError(w, logger, err)
+		return
+	}
+	writeJSON(w, logger, http.StatusOK, StatsResponse{
+		Length:            stats.Length,
+		Answers:           stats.Answers,
+		Guesses:           stats.Guesses,
+		Removed:           stats.Removed,
+		AnswersSource:     stats.AnswersSource,
+		GuessesSource:     stats.GuessesSource,
+		RemoveSource:      stats.RemoveSource,
+		FrequenciesSource: stats.FrequenciesSource,
+		HistorySource:     stats.HistorySource,
+		LoadedAt:          stats.LoadedAt,
+	})
+}
@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"wordle/usrcmd"
+	"wordle/wordle"
+)
+
+// maxRequestBytes limits the size of a JSON API request body
+const maxRequestBytes = 1 << 20
+
+// maxSuggestions is the most suggestions an API request may ask for
+const maxSuggestions = 100
+
+// Error codes returned by the JSON API
+const (
+	codeInvalidJSON        = "invalid_json"
+	codeInvalidGuess       = "invalid_guess"
+	codeInvalidConstraints = "invalid_constraints"
+	codeInvalidRequest     = "invalid_request"
+	codeUnknownStrategy    = "unknown_strategy"
+	codeInternal           = "internal_error"
+)
+
+// APIGuess is a guessed word and the pattern it scored. The pattern is one
+// tile per letter: g, y and x, or the emoji squares.
+type APIGuess struct {
+	Word    string `json:"word"`
+	Pattern string `json:"pattern"`
+}
+
+// SolveRequest is the body of POST /api/v1/solve and POST /api/v1/suggest.
+// Guesses and Constraints are combined; either may be left out.
+type SolveRequest struct {
+	Guesses []APIGuess `json:"guesses"`
+	// Constraints uses the command line form, e.g. "cne . -r a . . e=1"
+	Constraints string `json:"constraints"`
+	// Strategy ranks the suggestions (default entropy)
+	Strategy string `json:"strategy"`
+	// Limit is the most candidates to return (0 returns them all)
+	Limit int `json:"limit"`
+	// Suggestions is how many suggestions to return (default 10)
+	Suggestions int `json:"suggestions"`
+	// ExcludePast leaves out the words that were already the answer, if the
+	// server has the history of past answers
+	ExcludePast bool `json:"exclude_past"`
+}
+
+// SolveResponse is the result of POST /api/v1/solve
+type SolveResponse struct {
+	Constraints wordle.Constraints `json:"constraints"`
+	Count       int                `json:"count"`
+	Candidates  []string           `json:"candidates"`
+	Strategy    string             `json:"strategy"`
+	Suggestions []APISuggestion    `json:"suggestions"`
+}
+
+// SuggestResponse is the result of POST /api/v1/suggest
+type SuggestResponse struct {
+	Count       int             `json:"count"`
+	Strategy    string          `json:"strategy"`
+	Suggestions []APISuggestion `json:"suggestions"`
+}
+
+// APISuggestion is a ranked next guess
+type APISuggestion struct {
+	Word              string  `json:"word"`
+	Entropy           float64 `json:"entropy"`
+	ExpectedRemaining float64 `json:"expected_remaining"`
+	WorstCase         int     `json:"worst_case"`
+	Candidate         bool    `json:"candidate"`
+	Probability       float64 `json:"probability"`
+}
+
+// ScoreRequest is the body of POST /api/v1/score
+type ScoreRequest struct {
+	Guess  string `json:"guess"`
+	Answer string `json:"answer"`
+}
+
+// ScoreResponse is the result of POST /api/v1/score
+type ScoreResponse struct {
+	Guess   string `json:"guess"`
+	Answer  string `json:"answer"`
+	Pattern string `json:"pattern"`
+	Emoji   string `json:"emoji"`
+	Solved  bool   `json:"solved"`
+}
+
+// APIError is the body of every JSON API error response
+type APIError struct {
+	Error APIErrorDetail `json:"error"`
+}
+
+// APIErrorDetail describes what was wrong with a request
+type APIErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// apiError is an error with the status and code to report it with
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func badRequest(code string, format string, args ...any) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// HandleAPISolve returns the possible answers and the suggested next guesses
+func HandleAPISolve(logger *slog.Logger, wordList WordList) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SolveRequest
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeAPIError(w, logger, err)
+			return
+		}
+		sol, err := solve(req, wordList)
+		if err != nil {
+			writeAPIError(w, logger, err)
+			return
+		}
+		logger.Info("API solve", "count", len(sol.possibles), "strategy", sol.strategy.Name())
+
+		candidates := sol.possibles
+		if req.Limit > 0 && req.Limit < len(candidates) {
+			candidates = candidates[:req.Limit]
+		}
+		writeJSON(w, logger, http.StatusOK, SolveResponse{
+			Constraints: sol.constraints,
+			Count:       len(sol.possibles),
+			Candidates:  nonNil(candidates),
+			Strategy:    sol.strategy.Name(),
+			Suggestions: apiSuggestions(sol.rank(wordList)),
+		})
+	}
+}
+
+// HandleAPISuggest returns only the suggested next guesses
+func HandleAPISuggest(logger *slog.Logger, wordList WordList) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SolveRequest
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeAPIError(w, logger, err)
+			return
+		}
+		sol, err := solve(req, wordList)
+		if err != nil {
+			writeAPIError(w, logger, err)
+			return
+		}
+		logger.Info("API suggest", "count", len(sol.possibles), "strategy", sol.strategy.Name())
+
+		writeJSON(w, logger, http.StatusOK, SuggestResponse{
+			Count:       len(sol.possibles),
+			Strategy:    sol.strategy.Name(),
+			Suggestions: apiSuggestions(sol.rank(wordList)),
+		})
+	}
+}
+
+// HandleAPIScore returns the pattern a guess scores against an answer
+func HandleAPIScore(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ScoreRequest
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeAPIError(w, logger, err)
+			return
+		}
+		guess := strings.ToLower(strings.TrimSpace(req.Guess))
+		answer := strings.ToLower(strings.TrimSpace(req.Answer))
+		for _, word := range []string{guess, answer} {
+			if !isWord(word) {
+				writeAPIError(w, logger, badRequest(codeInvalidRequest, "%q is not a word of %d to %d letters",
+					word, wordle.MinWordLength, wordle.MaxWordLength))
+				return
+			}
+		}
+		if wordle.Len(guess) != wordle.Len(answer) {
+			writeAPIError(w, logger, badRequest(codeInvalidRequest, "the guess and the answer must have as many letters"))
+			return
+		}
+
+		pattern := wordle.Score(guess, answer)
+		writeJSON(w, logger, http.StatusOK, ScoreResponse{
+			Guess:   guess,
+			Answer:  answer,
+			Pattern: pattern.String(),
+			Emoji:   pattern.Emoji(),
+			Solved:  pattern.Solved(),
+		})
+	}
+}
+
+// solution is what a solve or suggest request works out
+type solution struct {
+	constraints wordle.Constraints
+	possibles   []string
+	strategy    wordle.Strategy
+	suggestions int // how many suggestions to rank
+}
+
+// rank returns the suggested next guesses. Before the first clue there are
+// none: ranking every guess against every answer takes seconds, too long to
+// spend on a request.
+func (sol solution) rank(wordList WordList) []wordle.Suggestion {
+	if !sol.constraints.HasClues() {
+		return nil
+	}
+	return sol.strategy.Rank(wordList.Guesses(), sol.possibles, sol.suggestions)
+}
+
+// solve combines the request's guesses and constraints and finds the possible
+// answers. The constraints and the guesses must be for words of the word
+// list's length.
+func solve(req SolveRequest, wordList WordList) (solution, error) {
+	strategy, err := wordle.StrategyByName(req.Strategy)
+	if err != nil {
+		return solution{}, badRequest(codeUnknownStrategy, "%s", err)
+	}
+	if req.Limit < 0 {
+		return solution{}, badRequest(codeInvalidRequest, "limit must not be negative")
+	}
+	n := req.Suggestions
+	switch {
+	case n == 0:
+		n = suggestionCount
+	case n < 0 || n > maxSuggestions:
+		return solution{}, badRequest(codeInvalidRequest, "suggestions must be between 1 and %d", maxSuggestions)
+	}
+
+	length := wordList.Length()
+	alphabet := wordList.Alphabet()
+	constraints := wordle.Constraints{Length: length}
+	if strings.TrimSpace(req.Constraints) != "" {
+		if constraints, err = wordle.ParseConstraints(alphabet.NormalizeText(req.Constraints)); err != nil {
+			return solution{}, badRequest(codeInvalidConstraints, "%s", err)
+		}
+		if err := constraints.CheckLen(length); err != nil {
+			return solution{}, badRequest(codeInvalidConstraints, "%s", err)
+		}
+	}
+	for i, g := range req.Guesses {
+		guess, err := usrcmd.ReadGuess(alphabet.NormalizeText(g.Word) + " " + g.Pattern)
+		if err != nil {
+			return solution{}, badRequest(codeInvalidGuess, "guess %d: %s", i+1, err)
+		}
+		clues := wordle.NewConstraints(guess)
+		if err := clues.CheckLen(length); err != nil {
+			return solution{}, badRequest(codeInvalidGuess, "guess %d: %s", i+1, err)
+		}
+		constraints = constraints.Merge(clues)
+	}
+	if err := constraints.Validate(); err != nil {
+		return solution{}, badRequest(codeInvalidConstraints, "%s", err)
+	}
+
+	// With a frequency table the likeliest answers come first
+	freqs := wordList.Frequencies()
+	possibles := wordList.Index().Possibles(constraints)
+	if req.ExcludePast {
+		possibles = wordList.History().ExcludeUsed(possibles, time.Now())
+	}
+	wordle.SortByLikelihood(possibles, freqs)
+
+	return solution{
+		constraints: constraints,
+		possibles:   possibles,
+		strategy:    wordle.WithPrior(strategy, freqs),
+		suggestions: n,
+	}, nil
+}
+
+// decodeJSON reads a single JSON object from the request body into v. Unknown
+// fields are rejected so that misspelled options aren't silently ignored.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var maxBytes *http.MaxBytesError
+		var unmarshalType *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &maxBytes):
+			return &apiError{
+				status:  http.StatusRequestEntityTooLarge,
+				code:    codeInvalidRequest,
+				message: fmt.Sprintf("request body is larger than %d bytes", maxBytes.Limit),
+			}
+		case errors.As(err, &unmarshalType):
+			return badRequest(codeInvalidJSON, "%s must be a %s", unmarshalType.Field, unmarshalType.Type)
+		}
+		return badRequest(codeInvalidJSON, "invalid request body: %s", err)
+	}
+	if dec.More() {
+		return badRequest(codeInvalidJSON, "request body must be a single JSON object")
+	}
+	return nil
+}
+
+// writeAPIError writes err as a JSON error response
+func writeAPIError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{status: http.StatusInternalServerError, code: codeInternal, message: err.Error()}
+	}
+	logger.Error("API error", "code", apiErr.code, "error", apiErr.message)
+	writeJSON(w, logger, apiErr.status, APIError{Error: APIErrorDetail{Code: apiErr.code, Message: apiErr.message}})
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, logger *slog.Logger, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error("Error writing JSON response", "error", err)
+	}
+}
+
+func apiSuggestions(suggestions []wordle.Suggestion) []APISuggestion {
+	out := make([]APISuggestion, len(suggestions))
+	for i, s := range suggestions {
+		out[i] = APISuggestion{
+			Word:              s.Word,
+			Entropy:           s.Entropy,
+			ExpectedRemaining: s.ExpectedRemaining,
+			WorstCase:         s.WorstCase,
+			Candidate:         s.Candidate,
+			Probability:       s.Probability,
+		}
+	}
+	return out
+}
+
+// isWord reports whether s is a word of lowercase letters, in any alphabet,
+// of a length that can be played
+func isWord(s string) bool {
+	if wordle.CheckLength(wordle.Len(s)) != nil {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// nonNil returns an empty slice instead of nil so it encodes as []
+func nonNil(words []string) []string {
+	if words == nil {
+		return []string{}
+	}
+	return words
+}
@@ -0,0 +1,272 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"wordle/dictionary"
+	"wordle/handlers"
+	"wordle/wordle"
+)
+
+type fakeWordList struct {
+	answers  []string
+	freqs    wordle.Frequencies
+	history  dictionary.History
+	length   int // 0 means wordle.WordLength
+	alphabet wordle.Alphabet
+}
+
+func (f fakeWordList) Answers() []string               { return f.answers }
+func (f fakeWordList) Guesses() []string               { return f.answers }
+func (f fakeWordList) Frequencies() wordle.Frequencies { return f.freqs }
+func (f fakeWordList) History() dictionary.History     { return f.history }
+func (f fakeWordList) Index() *wordle.Index            { return wordle.NewIndex(f.answers) }
+
+func (f fakeWordList) Alphabet() wordle.Alphabet { return f.alphabet }
+
+func (f fakeWordList) ListsOfLength(n int) dictionary.Lists {
+	return dictionary.Lists{Answers: f.answers, Guesses: f.answers}.OfLength(n)
+}
+
+func (f fakeWordList) Length() int {
+	if f.length == 0 {
+		return wordle.WordLength
+	}
+	return f.length
+}
+
+var testWords = fakeWordList{answers: []string{"abide", "baked", "baker", "crane", "guide", "split"}}
+
+func post(t *testing.T, h http.HandlerFunc, body string) (*http.Response, []byte) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	res := rec.Result()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	return res, data
+}
+
+func TestAPISolve(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := handlers.HandleAPISolve(logger, testWords)
+
+	res, body := post(t, h, `{"guesses":[{"word":"crane","pattern":"xxxxg"}],"suggestions":2}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	var got handlers.SolveResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"guide"}; got.Count != 1 || !slices.Equal(got.Candidates, want) {
+		t.Errorf("candidates = %d %v, want %v", got.Count, got.Candidates, want)
+	}
+	if got.Strategy != "entropy" || len(got.Suggestions) != 2 {
+		t.Errorf("strategy %q with %d suggestions, want entropy with 2", got.Strategy, len(got.Suggestions))
+	}
+
+	// The constraint text form works too, and limit trims the candidates
+	res, body = post(t, h, `{"constraints":"cnr . . . . .","limit":1}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Count != 4 || !slices.Equal(got.Candidates, []string{"abide"}) {
+		t.Errorf("candidates = %d %v, want 4 [abide]", got.Count, got.Candidates)
+	}
+
+	// Without a clue every answer is possible and nothing is ranked
+	res, body = post(t, h, `{}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	got = handlers.SolveResponse{}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Count != len(testWords.answers) || got.Suggestions == nil || len(got.Suggestions) != 0 {
+		t.Errorf("blank request: %d candidates and suggestions %v, want %d and none", got.Count, got.Suggestions, len(testWords.answers))
+	}
+}
+
+func TestAPISolveFrequencies(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	words := testWords
+	words.freqs = wordle.Frequencies{"guide": 100, "split": 50, "abide": 1}
+	h := handlers.HandleAPISolve(logger, words)
+
+	// The likeliest answers come first instead of the alphabetically first
+	res, body := post(t, h, `{"constraints":"cnr . . . . .","limit":2,"suggestions":1}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	var got handlers.SolveResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Count != 4 || !slices.Equal(got.Candidates, []string{"guide", "split"}) {
+		t.Errorf("candidates = %d %v, want 4 [guide split]", got.Count, got.Candidates)
+	}
+	if len(got.Suggestions) != 1 || got.Suggestions[0].Probability == 0 {
+		t.Errorf("suggestions = %+v, want one with a probability", got.Suggestions)
+	}
+}
+
+func TestAPISolveExcludePast(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	today := time.Now()
+	words := testWords
+	words.history = dictionary.History{
+		{Date: today.AddDate(0, 0, -2), Number: 1, Word: "abide"},
+		{Date: today.AddDate(0, 0, -1), Number: 2, Word: "split"},
+		{Date: today, Number: 3, Word: "guide"},
+	}
+	h := handlers.HandleAPISolve(logger, words)
+
+	// Today's answer is never left out, even when the history has it
+	for body, want := range map[string][]string{
+		`{"constraints":"cnr . . . . ."}`:                     {"abide", "baked", "guide", "split"},
+		`{"constraints":"cnr . . . . .","exclude_past":true}`: {"baked", "guide"},
+	} {
+		res, data := post(t, h, body)
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, body %s", res.StatusCode, data)
+		}
+		var got handlers.SolveResponse
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.Candidates, want) {
+			t.Errorf("%s: candidates = %v, want %v", body, got.Candidates, want)
+		}
+	}
+}
+
+func TestAPISolveLength(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	words := fakeWordList{answers: []string{"bingos", "bongos", "lingos", "tangos"}, length: 6}
+	h := handlers.HandleAPISolve(logger, words)
+
+	res, body := post(t, h, `{"guesses":[{"word":"bongos","pattern":"xxgggg"}]}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	var got handlers.SolveResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Candidates, []string{"lingos", "tangos"}) || got.Constraints.String() != "b . -o n g o s o=1" {
+		t.Errorf("candidates = %v with %q, want [lingos tangos]", got.Candidates, got.Constraints)
+	}
+
+	// Clues for five letter words don't fit
+	for _, body := range []string{
+		`{"guesses":[{"word":"crane","pattern":"xxxxg"}]}`,
+		`{"constraints":"x . . . . ."}`,
+	} {
+		if res, data := post(t, h, body); res.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, body %s, want 400", body, res.StatusCode, data)
+		}
+	}
+}
+
+func TestAPISolveAlphabet(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	spanish := wordle.Spanish
+	spanish.Fold = true
+	words := fakeWordList{answers: []string{"añejo", "arbol", "ñandu", "señal", "señor"}, alphabet: spanish}
+	h := handlers.HandleAPISolve(logger, words)
+
+	// Accents are folded but ñ is a letter of its own
+	res, body := post(t, h, `{"guesses":[{"word":"ÁRBOL","pattern":"gxxyx"}],"constraints":"u . . . . ."}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	var got handlers.SolveResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Candidates, []string{"añejo"}) {
+		t.Errorf("candidates = %v, want [añejo]", got.Candidates)
+	}
+}
+
+func TestAPIScore(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	res, body := post(t, handlers.HandleAPIScore(logger), `{"guess":"CRANE","answer":"guide"}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	var got handlers.ScoreResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := handlers.ScoreResponse{Guess: "crane", Answer: "guide", Pattern: "xxxxg", Emoji: "⬛⬛⬛⬛🟩"}
+	if got != want {
+		t.Errorf("score = %+v, want %+v", got, want)
+	}
+
+	res, body = post(t, handlers.HandleAPIScore(logger), `{"guess":"Señor","answer":"añejo"}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body %s", res.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Guess != "señor" || got.Pattern != "xyyyx" {
+		t.Errorf("score = %+v, want señor scored xyyyx", got)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	solve := handlers.HandleAPISolve(logger, testWords)
+	tests := []struct {
+		name string
+		h    http.HandlerFunc
+		body string
+		code string
+	}{
+		{"bad json", solve, `{"guesses":`, "invalid_json"},
+		{"unknown field", solve, `{"guess":[]}`, "invalid_json"},
+		{"wrong type", solve, `{"limit":"all"}`, "invalid_json"},
+		{"bad guess", solve, `{"guesses":[{"word":"cran","pattern":"xxxx"}]}`, "invalid_guess"},
+		{"bad constraints", solve, `{"constraints":"a b"}`, "invalid_constraints"},
+		{"conflict", solve, `{"guesses":[{"word":"crane","pattern":"gxxxx"},{"word":"split","pattern":"gxxxx"}]}`, "invalid_constraints"},
+		{"strategy", handlers.HandleAPISuggest(logger, testWords), `{"strategy":"bogus"}`, "unknown_strategy"},
+		{"suggestions", solve, `{"suggestions":-1}`, "invalid_request"},
+		{"score length", handlers.HandleAPIScore(logger), `{"guess":"cranes","answer":"guide"}`, "invalid_request"},
+		{"score too long", handlers.HandleAPIScore(logger), `{"guess":"abbreviations","answer":"abbreviations"}`, "invalid_request"},
+		{"constraints length", solve, `{"constraints":"x . . . . . ."}`, "invalid_constraints"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, body := post(t, tt.h, tt.body)
+			if res.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", res.StatusCode)
+			}
+			var got handlers.APIError
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("error body %s: %v", body, err)
+			}
+			if got.Error.Code != tt.code || got.Error.Message == "" {
+				t.Errorf("error = %+v, want code %s", got.Error, tt.code)
+			}
+		})
+	}
+}
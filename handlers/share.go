@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"wordle/components"
+	"wordle/share"
+)
+
+// HandlePostShare replays pasted NYT share text and shows how many words were
+// left after each row
+func HandlePostShare(logger *slog.Logger, wordList WordList) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Info("Replaying shared game")
+
+		if err := r.ParseForm(); err != nil {
+			logger.Error("Error parsing form", "error", err)
+			renderShareError(w, logger, "Invalid form data")
+			return
+		}
+
+		formData := components.ShareFormData{
+			Text:    r.FormValue("text"),
+			Guesses: strings.TrimSpace(r.FormValue("guesses")),
+			Answer:  strings.ToLower(strings.TrimSpace(r.FormValue("answer"))),
+		}
+
+		result, err := share.Parse(formData.Text)
+		if err != nil {
+			logger.Error("Error parsing share text", "error", err)
+			renderShareError(w, logger, "Invalid share text: "+err.Error())
+			return
+		}
+
+		guesses := share.SplitGuesses(formData.Guesses)
+
+		var steps []share.Step
+		switch {
+		case len(guesses) > 0:
+			steps, err = result.Replay(guesses, formData.Answer, wordList.Answers())
+		case formData.Answer != "":
+			steps, err = result.ReplayAnswer(formData.Answer, wordList.Guesses(), wordList.Answers())
+		}
+		if err != nil {
+			logger.Error("Error replaying shared game", "error", err)
+			renderShareError(w, logger, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+		if err := components.ShareResults(result, steps).Render(w); err != nil {
+			logger.Error("Error rendering view", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+	}
+}
+
+// renderShareError renders an error in place of the share results
+func renderShareError(w http.ResponseWriter, logger *slog.Logger, errMsg string) {
+	w.Header().Set("Content-Type", "text/html")
+	if err := components.ErrorAlert(errMsg).Render(w); err != nil {
+		logger.Error("Error rendering error view", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,58 @@
+package handlers_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"wordle/handlers"
+)
+
+const sharedGame = "Wordle 1,234 3/6\n\n⬛⬛⬛⬛🟩\n⬛⬛⬛🟨⬛\n🟩🟩🟩🟩🟩\n"
+
+func postShare(t *testing.T, form url.Values) string {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	req := httptest.NewRequest(http.MethodPost, "/wordle/share", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	handlers.HandlePostShare(logger, testWords)(rec, req)
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestPostShare(t *testing.T) {
+	body := postShare(t, url.Values{"text": {sharedGame}, "guesses": {"crane, split guide"}, "answer": {" Guide "}})
+	for _, want := range []string{"Wordle 1234 3/6", ">crane<", ">split<", ">guide<", "Words Left"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("results are missing %s:\n%s", want, body)
+		}
+	}
+
+	// With only the answer, the guesses that fit each row are counted
+	body = postShare(t, url.Values{"text": {sharedGame}, "answer": {"guide"}})
+	if !strings.Contains(body, "Words Left (at most)") {
+		t.Errorf("results don't count the possible guesses:\n%s", body)
+	}
+}
+
+func TestPostShareErrors(t *testing.T) {
+	tests := map[string]url.Values{
+		"Invalid share text":           {"text": {"Wordle 1,234 X/6\n\n⬛⬛⬛⬛🟩"}, "answer": {"guide"}},
+		`answer &#34;ab&#34; is not 5`: {"text": {sharedGame}, "guesses": {"crane split guide"}, "answer": {"ab"}},
+		"got 2 guesses for 3 rows":     {"text": {sharedGame}, "guesses": {"crane split"}},
+		"is not in the answer list":    {"text": {sharedGame}, "answer": {"slide"}},
+		"slate against guide scores":   {"text": {sharedGame}, "guesses": {"crane slate guide"}, "answer": {"guide"}},
+	}
+	for want, form := range tests {
+		if body := postShare(t, form); !strings.Contains(body, want) {
+			t.Errorf("%v: response doesn't report %q:\n%s", form, want, body)
+		}
+	}
+}
@@ -1,40 +1,75 @@
 package handlers
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 	"wordle/components"
+	"wordle/dictionary"
 	"wordle/usrcmd"
 	"wordle/wordle"
+
+	g "github.com/maragudk/gomponents"
 )
 
 // WordList interface for dictionary management
 type WordList interface {
-	Words() []string
+	// Answers are the words that could be the answer
+	Answers() []string
+	// Index has the answers prepared for filtering by constraints
+	Index() *wordle.Index
+	// Guesses are the words that may be guessed, including the answers
+	Guesses() []string
+	// Frequencies tells how common the words are, or is nil if unknown
+	Frequencies() wordle.Frequencies
+	// History is the past official answers, or nil if unknown
+	History() dictionary.History
+	// Length is how many letters the words have
+	Length() int
+	// ListsOfLength is the words of n letters, for playing another length
+	ListsOfLength(n int) dictionary.Lists
+	// Alphabet is the alphabet the words are in
+	Alphabet() wordle.Alphabet
 }
 
+// suggestionCount is how many next-guess suggestions to show with the results
+const suggestionCount = 10
+
 // FormData represents the form input from the user
 // This is an alias to the components.FormData type for convenience
 type FormData = components.FormData
 
-// HandleGetForm renders the initial empty form
-func HandleGetForm(logger *slog.Logger) http.HandlerFunc {
+// HandleGetForm renders the initial empty form, for the word length in the
+// length query parameter if there is one. Past answers are left out by default
+// when they're known.
+func HandleGetForm(logger *slog.Logger, wordList WordList) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Info("Getting Wordle form")
 
+		length, err := readLength(r, wordList)
+		if err != nil {
+			logger.Error("Error reading word length", "error", err)
+			length := wordList.Length()
+			renderError(w, logger, err.Error(), FormData{Rows: components.EmptyGuessRows(length), Positions: blankPositions(length)})
+			return
+		}
+
+		hasHistory := len(wordList.History()) > 0
 		data := FormData{
-			Missed: "",
-			Pos0:   ".",
-			Pos1:   ".",
-			Pos2:   ".",
-			Pos3:   ".",
-			Pos4:   ".",
+			Rows:        components.EmptyGuessRows(length),
+			Missed:      "",
+			Positions:   blankPositions(length),
+			Strategy:    wordle.Entropy.Name(),
+			HasHistory:  hasHistory,
+			ExcludePast: hasHistory,
 		}
 
 		page := components.Page("Wordle Helper", components.WordleForm(data, ""))
 		w.Header().Set("Content-Type", "text/html")
-		err := page.Render(w)
+		err = page.Render(w)
 		if err != nil {
 			logger.Error("Error rendering view", "error", err)
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -48,43 +83,85 @@ func HandlePostSolve(logger *slog.Logger, wordList WordList) http.HandlerFunc {
 		logger.Info("Solving Wordle")
 
 		// Parse form data
+		length := wordList.Length()
 		if err := r.ParseForm(); err != nil {
 			logger.Error("Error parsing form", "error", err)
-			renderError(w, logger, "Invalid form data", FormData{})
+			renderError(w, logger, "Invalid form data", FormData{Positions: blankPositions(length)})
+			return
+		}
+		length, err := readLength(r, wordList)
+		if err != nil {
+			logger.Error("Error reading word length", "error", err)
+			length = wordList.Length()
+			renderError(w, logger, err.Error(), FormData{Rows: components.EmptyGuessRows(length), Positions: blankPositions(length)})
 			return
 		}
 
+		// Letters are normalized to the word list's alphabet, so accents are
+		// folded if it folds them
+		alphabet := wordList.Alphabet()
 		formData := FormData{
-			Missed: strings.TrimSpace(r.FormValue("missed")),
-			Pos0:   strings.TrimSpace(r.FormValue("pos0")),
-			Pos1:   strings.TrimSpace(r.FormValue("pos1")),
-			Pos2:   strings.TrimSpace(r.FormValue("pos2")),
-			Pos3:   strings.TrimSpace(r.FormValue("pos3")),
-			Pos4:   strings.TrimSpace(r.FormValue("pos4")),
+			Rows:      readGuessRows(r, length, alphabet),
+			Guesses:   alphabet.NormalizeText(strings.TrimSpace(r.FormValue("guesses"))),
+			Missed:    alphabet.NormalizeText(strings.TrimSpace(r.FormValue("missed"))),
+			Positions: readPositions(r, length, alphabet),
+			Counts:    alphabet.NormalizeText(strings.TrimSpace(r.FormValue("counts"))),
+			Strategy:  strings.TrimSpace(r.FormValue("strategy")),
 		}
-
-		// Normalize empty positions to dots
-		normalizePosition(&formData.Pos0)
-		normalizePosition(&formData.Pos1)
-		normalizePosition(&formData.Pos2)
-		normalizePosition(&formData.Pos3)
-		normalizePosition(&formData.Pos4)
+		history := wordList.History()
+		formData.HasHistory = len(history) > 0
+		formData.ExcludePast = formData.HasHistory && r.FormValue("exclude_past") != ""
 
 		// Convert form data to wordle types
-		missed, lettersAt, lettersNotAt, err := parseFormToWordleInputs(formData)
+		constraints, err := parseFormToConstraints(formData)
 		if err != nil {
 			logger.Error("Error parsing wordle inputs", "error", err)
 			renderError(w, logger, "Invalid input format: "+err.Error(), formData)
 			return
 		}
 
-		// Get current word list (thread-safe)
-		words := wordList.Words()
+		// Find possible words, narrowing after each row of the grid. Only the
+		// words of the server's length are indexed; other lengths are filtered
+		// one by one.
+		var possibles []string
+		var total int
+		guesses := wordList.Guesses
+		if length == wordList.Length() {
+			index := wordList.Index()
+			possibles, total = index.Possibles(constraints), index.Len()
+		} else {
+			lists := wordList.ListsOfLength(length)
+			possibles, total = wordle.MakePossibles(lists.Answers, constraints), len(lists.Answers)
+			guesses = func() []string { return lists.Guesses }
+		}
+		if formData.ExcludePast {
+			possibles = history.ExcludeUsed(possibles, time.Now())
+		}
+		possibles, err = narrowByRows(possibles, constraints, formData.Rows, length)
+		if err != nil {
+			logger.Error("Error reading guess grid", "error", err)
+			renderError(w, logger, "Invalid guess: "+err.Error(), formData)
+			return
+		}
 
-		// Find possible words
-		possibles := wordle.MakePossibles(words, missed, lettersAt, lettersNotAt)
+		logger.Info("Found possible words", "count", len(possibles), "total_words", total)
 
-		logger.Info("Found possible words", "count", len(possibles), "total_words", len(words))
+		// Rank next guesses with the selected strategy
+		strategy, err := wordle.StrategyByName(formData.Strategy)
+		if err != nil {
+			logger.Error("Error selecting strategy", "error", err)
+			renderError(w, logger, err.Error(), formData)
+			return
+		}
+		freqs := wordList.Frequencies()
+		strategy = wordle.WithPrior(strategy, freqs)
+		// Ranking every guess against every answer takes seconds, so wait for
+		// the first clue
+		var suggestions []wordle.Suggestion
+		if constraints.HasClues() || hasGuessRows(formData.Rows) {
+			suggestions = strategy.Rank(guesses(), possibles, suggestionCount)
+		}
+		wordle.SortByLikelihood(possibles, freqs)
 
 		// Check if this is an HTMX request - if so, render only the results partial
 		isHTMX := r.Header.Get("HX-Request") == "true"
@@ -93,7 +170,10 @@ func HandlePostSolve(logger *slog.Logger, wordList WordList) http.HandlerFunc {
 
 		if isHTMX {
 			// Render just the results partial
-			results := components.Results(possibles, len(possibles))
+			results := g.Group{
+				components.Results(possibles, len(possibles), strategy.Name(), suggestions, freqs != nil),
+				components.RowCounts(formData.Rows),
+			}
 			err = results.Render(w)
 		} else {
 			// Render full page (for non-HTMX fallback)
@@ -108,29 +188,144 @@ func HandlePostSolve(logger *slog.Logger, wordList WordList) http.HandlerFunc {
 	}
 }
 
-// normalizePosition converts empty strings to dots
-func normalizePosition(pos *string) {
-	if *pos == "" {
-		*pos = "."
+// readLength reads the word length the form is for, which is the word
+// list's length unless the length field says otherwise.
+func readLength(r *http.Request, wordList WordList) (int, error) {
+	s := strings.TrimSpace(r.FormValue("length"))
+	if s == "" {
+		return wordList.Length(), nil
 	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid word length %q", s)
+	}
+	return n, wordle.CheckLength(n)
 }
 
-// parseFormToWordleInputs converts form data to wordle types using existing usrcmd logic
-func parseFormToWordleInputs(formData FormData) (string, []wordle.LetterAt, []wordle.LettersNotAt, error) {
+// blankPositions returns length unknown positions
+func blankPositions(length int) []string {
+	positions := make([]string, length)
+	for i := range positions {
+		positions[i] = "."
+	}
+	return positions
+}
+
+// readPositions reads the position boxes pos0 to pos(length-1). Empty
+// positions become dots.
+func readPositions(r *http.Request, length int, alphabet wordle.Alphabet) []string {
+	positions := make([]string, length)
+	for i := range positions {
+		positions[i] = alphabet.NormalizeText(strings.TrimSpace(r.FormValue(fmt.Sprintf("pos%d", i))))
+		if positions[i] == "" {
+			positions[i] = "."
+		}
+	}
+	return positions
+}
+
+// parseFormToConstraints converts form data to wordle constraints using existing usrcmd logic
+func parseFormToConstraints(formData FormData) (wordle.Constraints, error) {
 	// Build command line format that usrcmd expects
-	positions := []string{
-		formData.Pos0,
-		formData.Pos1,
-		formData.Pos2,
-		formData.Pos3,
-		formData.Pos4,
+	positions := formData.Positions
+	length := len(positions)
+	for i, pos := range positions {
+		if wordle.Len(pos) != 1 && !strings.HasPrefix(pos, "-") {
+			return wordle.Constraints{}, fmt.Errorf("position %d must be a letter, - and letters, or .", i+1)
+		}
+	}
+
+	missed := formData.Missed
+	if missed == "" {
+		missed = "."
 	}
 
 	// Create space-separated string like CLI input
-	cmdLine := formData.Missed + " " + strings.Join(positions, " ")
+	cmdLine := missed + " " + strings.Join(positions, " ")
+	if counts := strings.Fields(formData.Counts); len(counts) > 0 {
+		cmdLine += " " + strings.Join(counts, " ")
+	}
 
 	// Use existing command line parser
-	return usrcmd.ReadUserCommand(cmdLine)
+	constraints, err := usrcmd.ReadUserCommand(cmdLine)
+	if err != nil {
+		return wordle.Constraints{}, err
+	}
+	if err := constraints.CheckLen(length); err != nil {
+		return wordle.Constraints{}, err
+	}
+
+	// Add the clues from any scored guesses
+	guesses, err := usrcmd.ReadGuesses(formData.Guesses)
+	if err != nil {
+		return wordle.Constraints{}, err
+	}
+	for _, guess := range guesses {
+		if err := wordle.NewConstraints(guess).CheckLen(length); err != nil {
+			return wordle.Constraints{}, err
+		}
+	}
+	constraints = constraints.Merge(wordle.NewConstraints(guesses...))
+
+	return constraints, constraints.Validate()
+}
+
+// readGuessRows reads the guess grid. Each row is submitted as a guess field
+// and a pattern field, in order.
+func readGuessRows(r *http.Request, length int, alphabet wordle.Alphabet) []components.GuessRow {
+	words := r.Form["guess"]
+	patterns := r.Form["pattern"]
+	rows := make([]components.GuessRow, len(words))
+	for i, word := range words {
+		rows[i] = components.GuessRow{Word: alphabet.NormalizeText(strings.TrimSpace(word)), Remaining: -1}
+		if i < len(patterns) {
+			rows[i].Pattern = strings.TrimSpace(patterns[i])
+		}
+	}
+	if len(rows) == 0 {
+		return components.EmptyGuessRows(length)
+	}
+	return rows
+}
+
+// hasGuessRows reports whether any row of the guess grid is filled in.
+func hasGuessRows(rows []components.GuessRow) bool {
+	for _, row := range rows {
+		if row.Word != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// narrowByRows adds the clues from each filled-in grid row to constraints in
+// turn, narrowing possibles and recording how many words are left after each
+// row. It returns the words left after the last row. Every guess must have
+// length letters.
+func narrowByRows(possibles []string, constraints wordle.Constraints, rows []components.GuessRow, length int) ([]string, error) {
+	for i := range rows {
+		row := &rows[i]
+		if row.Word == "" {
+			continue
+		}
+		guess, err := usrcmd.ReadGuess(row.Word + " " + row.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		row.Pattern = guess.Pattern.String()
+
+		clues := wordle.NewConstraints(guess)
+		if err := clues.CheckLen(length); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		constraints = constraints.Merge(clues)
+		if err := constraints.Validate(); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		possibles = wordle.MakePossibles(possibles, constraints)
+		row.Remaining = len(possibles)
+	}
+	return possibles, nil
 }
 
 // renderError renders the form with an error message
@@ -143,4 +338,3 @@ func renderError(w http.ResponseWriter, logger *slog.Logger, errMsg string, form
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
 }
-
@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"wordle/handlers"
+)
+
+// postForm submits the solve form for words and returns the page or, for an HTMX
+// request, the results partial.
+func postForm(t *testing.T, words handlers.WordList, form url.Values, htmx bool) string {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	req := httptest.NewRequest(http.MethodPost, "/wordle/solve", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if htmx {
+		req.Header.Set("HX-Request", "true")
+	}
+	rec := httptest.NewRecorder()
+	handlers.HandlePostSolve(logger, words)(rec, req)
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestPostSolveGrid(t *testing.T) {
+	form := url.Values{
+		"guess":   {"split", "CRANE", ""},
+		"pattern": {"xxxyx", "⬛⬛⬛⬛🟩", ""},
+	}
+
+	// Each row's count is swapped in after the results
+	body := postForm(t, testWords, form, true)
+	for _, want := range []string{
+		`<span id="row-count-0" class="row-count" hx-swap-oob="true">2 left</span>`,
+		`<span id="row-count-1" class="row-count" hx-swap-oob="true">1 left</span>`,
+		`<span id="row-count-2" class="row-count" hx-swap-oob="true"></span>`,
+		"guide",
+		"Suggested Next Guesses",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("results are missing %s:\n%s", want, body)
+		}
+	}
+
+	// The full page shows the rows with their patterns as letters
+	body = postForm(t, testWords, form, false)
+	for _, want := range []string{`value="crane"`, `value="xxxxg"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page is missing %s", want)
+		}
+	}
+}
+
+func TestPostSolveGridErrors(t *testing.T) {
+	tests := map[string]url.Values{
+		"row 2: pattern":    {"guess": {"split", "crane"}, "pattern": {"xxxyx", "xxgg"}},
+		"row 1: guess":      {"guess": {"cr4ne"}, "pattern": {"xxxxx"}},
+		"row 2: position 5": {"guess": {"crane", "split"}, "pattern": {"xxxxg", "xxxxg"}},
+	}
+	for want, form := range tests {
+		body := postForm(t, testWords, form, false)
+		if !strings.Contains(body, "Invalid guess: "+want) {
+			t.Errorf("%v: page doesn't report %q", form, want)
+		}
+	}
+}
+
+func TestPostSolveNoClues(t *testing.T) {
+	body := postForm(t, testWords, url.Values{"guess": {"", ""}, "pattern": {"", ""}}, true)
+	if !strings.Contains(body, "6 found") {
+		t.Errorf("results don't list every word:\n%s", body)
+	}
+	if strings.Contains(body, "Suggested Next Guesses") {
+		t.Errorf("guesses were ranked without a clue")
+	}
+}
+
+func TestPostSolveLength(t *testing.T) {
+	words := fakeWordList{answers: []string{"crane", "split", "bingos", "bongos", "lingos", "tangos"}}
+
+	body := postForm(t, words, url.Values{"length": {"6"}, "guess": {"bongos"}, "pattern": {"xxgggg"}}, true)
+	for _, want := range []string{"2 found", "lingos", "tangos", `<span id="row-count-0" class="row-count" hx-swap-oob="true">2 left</span>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("results are missing %s:\n%s", want, body)
+		}
+	}
+
+	// The page has a box per letter and keeps the length selected
+	body = postForm(t, words, url.Values{"length": {"6"}, "pos0": {"b"}}, false)
+	for _, want := range []string{`name="pos5"`, `<option value="6" selected>`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page is missing %s", want)
+		}
+	}
+	if strings.Contains(body, `name="pos6"`) {
+		t.Errorf("page has a seventh position box")
+	}
+
+	// Clues must fit the length
+	body = postForm(t, words, url.Values{"length": {"6"}, "guess": {"crane"}, "pattern": {"xxxxg"}}, false)
+	if !strings.Contains(body, "Invalid guess: row 1") {
+		t.Errorf("five letter guess was accepted for six letter words")
+	}
+
+	for _, length := range []string{"2", "11", "six"} {
+		body := postForm(t, words, url.Values{"length": {length}}, false)
+		if !strings.Contains(body, "word length") {
+			t.Errorf("length %s: page doesn't report the length", length)
+		}
+	}
+}
+
+func TestGetFormLength(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	rec := httptest.NewRecorder()
+	handlers.HandleGetForm(logger, testWords)(rec, httptest.NewRequest(http.MethodGet, "/?length=7", nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, `name="pos6"`) || strings.Contains(body, `name="pos7"`) {
+		t.Errorf("form for length 7 doesn't have seven position boxes")
+	}
+	if !strings.Contains(body, `<option value="7" selected>`) {
+		t.Errorf("form doesn't select length 7")
+	}
+}
@@ -0,0 +1,227 @@
+package share
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"wordle/wordle"
+)
+
+// header matches the first line of the NYT share text, e.g. "Wordle 1,234 4/6*".
+var header = regexp.MustCompile(`Wordle\s+([\d,.\s]*\d)\s+([\dX])/(\d+)(\*?)`)
+
+// Result is a game as shared from the NYT Wordle.
+type Result struct {
+	// Number is the puzzle number.
+	Number int
+	// Score is how many guesses the game took, or 0 if it was lost.
+	Score int
+	// MaxGuesses is the number of guesses allowed.
+	MaxGuesses int
+	// HardMode is true when the game was played in hard mode.
+	HardMode bool
+	// Rows is the pattern of each guess, in order.
+	Rows []wordle.Pattern
+}
+
+// Solved reports whether the game was won.
+func (r Result) Solved() bool {
+	return r.Score > 0
+}
+
+// Parse reads share text such as
+//
+//	Wordle 1,234 3/6
+//
+//	⬛🟨⬛⬛⬛
+//	🟨🟩⬛🟨⬛
+//	🟩🟩🟩🟩🟩
+//
+// Lines other than the header and the rows, such as links, are ignored.
+func Parse(text string) (Result, error) {
+	m := header.FindStringSubmatch(text)
+	if m == nil {
+		return Result{}, fmt.Errorf(`no "Wordle <number> <score>/<max>" header found`)
+	}
+
+	var r Result
+	number := strings.Map(func(c rune) rune {
+		if c >= '0' && c <= '9' {
+			return c
+		}
+		return -1
+	}, m[1])
+	r.Number, _ = strconv.Atoi(number)
+	if m[2] != "X" {
+		r.Score, _ = strconv.Atoi(m[2])
+	}
+	r.MaxGuesses, _ = strconv.Atoi(m[3])
+	r.HardMode = m[4] == "*"
+
+	for _, line := range strings.Split(text[strings.Index(text, m[0])+len(m[0]):], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || !isRow(line) {
+			continue
+		}
+		pattern, err := wordle.ParsePattern(line)
+		if err != nil {
+			return Result{}, err
+		}
+		r.Rows = append(r.Rows, pattern)
+	}
+
+	if err := r.validate(); err != nil {
+		return Result{}, err
+	}
+	return r, nil
+}
+
+// isRow reports whether the line is made only of emoji squares.
+func isRow(line string) bool {
+	for _, c := range line {
+		switch c {
+		case '🟩', '🟨', '⬛', '⬜', '🟧', '🟦', '\ufe0f':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (r Result) validate() error {
+	if len(r.Rows) == 0 {
+		return fmt.Errorf("no rows found")
+	}
+	if r.Score > r.MaxGuesses {
+		return fmt.Errorf("score %d/%d is more than the maximum", r.Score, r.MaxGuesses)
+	}
+	length := r.Rows[0].Len()
+	for i, row := range r.Rows {
+		if row.Len() != length {
+			return fmt.Errorf("row %d has %d tiles, want %d", i+1, row.Len(), length)
+		}
+		if row.Solved() && i != len(r.Rows)-1 {
+			return fmt.Errorf("row %d is solved but is not the last row", i+1)
+		}
+	}
+	switch {
+	case r.Solved() && len(r.Rows) != r.Score:
+		return fmt.Errorf("score is %d but there are %d rows", r.Score, len(r.Rows))
+	case r.Solved() && !r.Rows[len(r.Rows)-1].Solved():
+		return fmt.Errorf("the game is solved but the last row is not")
+	case !r.Solved() && r.Rows[len(r.Rows)-1].Solved():
+		return fmt.Errorf("the game is lost but the last row is solved")
+	case !r.Solved() && len(r.Rows) != r.MaxGuesses:
+		return fmt.Errorf("the game is lost but there are %d rows, not %d", len(r.Rows), r.MaxGuesses)
+	}
+	return nil
+}
+
+// Step is what was known after one row of a shared game.
+type Step struct {
+	// Guess is the word played, if known.
+	Guess   string
+	Pattern wordle.Pattern
+	// Remaining is the number of answers still possible after this row.
+	Remaining int
+	// Guesses are the words that could have produced this row's pattern
+	// against the answer, when the guess itself is not known.
+	Guesses []string
+}
+
+// SplitGuesses splits a list of guesses separated by commas or white space,
+// such as "crane, split guide".
+func SplitGuesses(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+// Replay pairs each row with the word that was guessed and counts how many
+// answers remained possible after each row. If answer is not empty, every
+// row's pattern is checked against it and it must be one of answers. It's an
+// error if no answer fits a row, as when answers are for words of another
+// length.
+func (r Result) Replay(guesses []string, answer string, answers []string) ([]Step, error) {
+	if len(guesses) != len(r.Rows) {
+		return nil, fmt.Errorf("got %d guesses for %d rows", len(guesses), len(r.Rows))
+	}
+	if answer != "" && wordle.Len(answer) != r.Rows[0].Len() {
+		return nil, fmt.Errorf("answer %q is not %d letters", answer, r.Rows[0].Len())
+	}
+	if answer != "" && !slices.Contains(answers, answer) {
+		return nil, fmt.Errorf("answer %q is not in the answer list", answer)
+	}
+
+	var steps []Step
+	var played []wordle.Guess
+	candidates := answers
+	for i, row := range r.Rows {
+		guess := strings.ToLower(strings.TrimSpace(guesses[i]))
+		if wordle.Len(guess) != row.Len() {
+			return nil, fmt.Errorf("guess %q is not %d letters", guess, row.Len())
+		}
+		if answer != "" && wordle.Score(guess, answer) != row {
+			return nil, fmt.Errorf("%s against %s scores %s, but row %d is %s", guess, answer, wordle.Score(guess, answer).Emoji(), i+1, row.Emoji())
+		}
+		played = append(played, wordle.Guess{Word: guess, Pattern: row})
+		c := wordle.NewConstraints(played...)
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("row %d contradicts the rows before it: %w", i+1, err)
+		}
+		candidates = wordle.MakePossibles(candidates, c)
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("no answer in the list fits row %d", i+1)
+		}
+		steps = append(steps, Step{Guess: guess, Pattern: row, Remaining: len(candidates)})
+	}
+	return steps, nil
+}
+
+// ReplayAnswer is used when the answer is known but the guesses are not. For
+// each row it finds the words that could have been guessed to produce it. The
+// answers remaining after each row are those that every row's candidate
+// guesses could still have scored the same way, so the count is an upper
+// bound on what the player actually had left. answer must be one of answers.
+func (r Result) ReplayAnswer(answer string, guesses, answers []string) ([]Step, error) {
+	if wordle.Len(answer) != r.Rows[0].Len() {
+		return nil, fmt.Errorf("answer %q is not %d letters", answer, r.Rows[0].Len())
+	}
+	if !slices.Contains(answers, answer) {
+		return nil, fmt.Errorf("answer %q is not in the answer list", answer)
+	}
+
+	var steps []Step
+	candidates := answers
+	for i, row := range r.Rows {
+		var possible []string
+		for _, guess := range guesses {
+			if wordle.Len(guess) == wordle.Len(answer) && wordle.Score(guess, answer) == row {
+				possible = append(possible, guess)
+			}
+		}
+		if len(possible) == 0 {
+			return nil, fmt.Errorf("no guess scores %s against %s (row %d)", row.Emoji(), answer, i+1)
+		}
+
+		// Keep an answer if any of the possible guesses could not have told it
+		// apart from the real answer.
+		var kept []string
+		for _, candidate := range candidates {
+			if wordle.Len(candidate) != wordle.Len(answer) {
+				continue
+			}
+			for _, guess := range possible {
+				if wordle.Score(guess, candidate) == row {
+					kept = append(kept, candidate)
+					break
+				}
+			}
+		}
+		candidates = kept
+		steps = append(steps, Step{Pattern: row, Remaining: len(candidates), Guesses: possible})
+	}
+	return steps, nil
+}
@@ -0,0 +1,145 @@
+package share_test
+
+import (
+	"slices"
+	"testing"
+	"wordle/share"
+	"wordle/wordle"
+)
+
+const shared = `Wordle 1,234 3/6*
+
+⬛⬛⬛⬛🟩
+⬛⬛⬛🟨⬛
+🟩🟩🟩🟩🟩
+https://www.nytimes.com/games/wordle
+`
+
+var answers = []string{"abide", "aside", "glide", "guide", "oxide", "pride", "slide", "snide", "tulip"}
+
+func TestParse(t *testing.T) {
+	r, err := share.Parse(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Number != 1234 || r.Score != 3 || r.MaxGuesses != 6 || !r.HardMode || !r.Solved() {
+		t.Errorf("Parse() = %+v", r)
+	}
+	want := []string{"xxxxg", "xxxyx", "ggggg"}
+	if len(r.Rows) != len(want) {
+		t.Fatalf("Parse() has %d rows, want %d", len(r.Rows), len(want))
+	}
+	for i, row := range r.Rows {
+		if row.String() != want[i] {
+			t.Errorf("row %d = %s, want %s", i+1, row, want[i])
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"no header":        "⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩",
+		"no rows":          "Wordle 1,234 3/6",
+		"wrong row count":  "Wordle 1,234 3/6\n\n🟩🟩🟩🟩🟩",
+		"last row":         "Wordle 1,234 2/6\n\n⬛🟨⬛⬛🟩\n⬛🟨⬛⬛🟩",
+		"lost but solved":  "Wordle 1,234 X/6\n\n⬛🟨⬛⬛🟩\n🟩🟩🟩🟩🟩",
+		"uneven rows":      "Wordle 1,234 2/6\n\n⬛🟨⬛⬛\n🟩🟩🟩🟩🟩",
+		"solved too early": "Wordle 1,234 2/6\n\n🟩🟩🟩🟩🟩\n🟩🟩🟩🟩🟩",
+		"lost too early":   "Wordle 1,234 X/6\n\n⬛🟨⬛⬛🟩",
+	}
+	for name, text := range tests {
+		if _, err := share.Parse(text); err == nil {
+			t.Errorf("%s: Parse() error = nil, want error", name)
+		}
+	}
+}
+
+func TestParseLost(t *testing.T) {
+	r, err := share.Parse("Wordle 987 X/6\n\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Number != 987 || r.Solved() || len(r.Rows) != 6 {
+		t.Errorf("Parse() = %+v", r)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	r, err := share.Parse(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps, err := r.Replay([]string{"crane", "split", "guide"}, "guide", answers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRemaining := []int{4, 2, 1}
+	for i, step := range steps {
+		if step.Remaining != wantRemaining[i] {
+			t.Errorf("row %d remaining = %d, want %d", i+1, step.Remaining, wantRemaining[i])
+		}
+	}
+
+	if _, err := r.Replay([]string{"crane", "slate", "guide"}, "guide", answers); err == nil {
+		t.Errorf("Replay() with a guess that doesn't match its row error = nil, want error")
+	}
+	if _, err := r.Replay([]string{"crane"}, "", answers); err == nil {
+		t.Errorf("Replay() with too few guesses error = nil, want error")
+	}
+	if _, err := r.Replay([]string{"crane", "split", "guide"}, "abc", answers); err == nil {
+		t.Errorf("Replay() with an answer of the wrong length error = nil, want error")
+	}
+	if _, err := r.Replay([]string{"crane", "split", "guide"}, "guide", []string{"abide", "slide"}); err == nil {
+		t.Errorf("Replay() with an answer that isn't in the list error = nil, want error")
+	}
+	sixes := []string{"guided", "slides"}
+	if _, err := r.Replay([]string{"crane", "split", "guide"}, "", sixes); err == nil {
+		t.Errorf("Replay() against six letter answers error = nil, want error")
+	}
+}
+
+func TestReplayAnswer(t *testing.T) {
+	r, err := share.Parse(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guesses := append([]string{"crane", "split", "bravo"}, answers...)
+	steps, err := r.ReplayAnswer("guide", guesses, append([]string{"guided"}, answers...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 3 {
+		t.Fatalf("ReplayAnswer() returned %d steps, want 3", len(steps))
+	}
+	for i, step := range steps {
+		for _, guess := range step.Guesses {
+			if wordle.Score(guess, "guide") != step.Pattern {
+				t.Errorf("row %d: %s does not produce %s", i+1, guess, step.Pattern)
+			}
+		}
+		if step.Remaining < 1 {
+			t.Errorf("row %d: remaining = %d, want at least the answer", i+1, step.Remaining)
+		}
+	}
+	if last := steps[2]; len(last.Guesses) != 1 || last.Guesses[0] != "guide" || last.Remaining != 1 {
+		t.Errorf("last step = %+v, want guide with 1 remaining", last)
+	}
+}
+
+func TestSplitGuesses(t *testing.T) {
+	got := share.SplitGuesses("crane, split\tguide\nslate ")
+	want := []string{"crane", "split", "guide", "slate"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SplitGuesses() = %q, want %q", got, want)
+	}
+}
+
+func TestReplayAnswerNotInList(t *testing.T) {
+	r, err := share.Parse(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReplayAnswer("guide", answers, []string{"guided", "slides"}); err == nil {
+		t.Errorf("ReplayAnswer() with an answer that isn't in the list error = nil, want error")
+	}
+}
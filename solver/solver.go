@@ -0,0 +1,100 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"wordle/wordle"
+)
+
+// Turn is one guess in a game and what it revealed.
+type Turn struct {
+	Guess   string
+	Pattern wordle.Pattern
+	// Remaining is the number of candidates left after the guess.
+	Remaining int
+}
+
+// Game is the full trace of a simulated game.
+type Game struct {
+	Answer string
+	Turns  []Turn
+}
+
+// Solved reports whether the last guess was the answer.
+func (g Game) Solved() bool {
+	return len(g.Turns) > 0 && g.Turns[len(g.Turns)-1].Pattern.Solved()
+}
+
+// Solver plays games by asking a strategy for each guess. The zero value is
+// not usable; Answers, Guesses and Strategy must be set. A Solver is safe for
+// concurrent use once it has started playing, as long as its fields are not
+// changed.
+type Solver struct {
+	// Answers are the words that could be the answer.
+	Answers []string
+	// Guesses are the words the strategy may choose from.
+	Guesses []string
+	// Strategy picks each guess.
+	Strategy wordle.Strategy
+	// Opener is the first guess. If empty, the strategy picks it.
+	Opener string
+	// MaxTurns stops the game after that many guesses. Zero plays until the
+	// answer is found.
+	MaxTurns int
+
+	// next caches the guess chosen after each history of turns. The strategy
+	// is deterministic, so games that reveal the same patterns make the same
+	// choices and only the first game to reach a position pays for ranking.
+	next sync.Map
+}
+
+// Play simulates a game against answer: it guesses, scores the guess, narrows
+// the candidates and repeats until the answer is found.
+func (s *Solver) Play(answer string) (Game, error) {
+	game := Game{Answer: answer}
+	candidates := s.Answers
+	var constraints wordle.Constraints
+	var history strings.Builder
+
+	for s.MaxTurns == 0 || len(game.Turns) < s.MaxTurns {
+		if len(candidates) == 0 {
+			return game, fmt.Errorf("%s is not in the answer list", answer)
+		}
+		guess := s.nextGuess(history.String(), candidates, len(game.Turns) == 0)
+		if wordle.Len(guess) != wordle.Len(answer) {
+			return game, fmt.Errorf("cannot play %q against %q: lengths differ", guess, answer)
+		}
+
+		pattern := wordle.Score(guess, answer)
+		constraints = constraints.Merge(wordle.NewConstraints(wordle.Guess{Word: guess, Pattern: pattern}))
+		narrowed := wordle.MakePossibles(candidates, constraints)
+		game.Turns = append(game.Turns, Turn{Guess: guess, Pattern: pattern, Remaining: len(narrowed)})
+		if pattern.Solved() {
+			return game, nil
+		}
+		if len(narrowed) == len(candidates) && (len(game.Turns) > 1 || s.Opener == "") {
+			return game, fmt.Errorf("%s strategy made no progress with %q", s.Strategy.Name(), guess)
+		}
+
+		candidates = narrowed
+		_, _ = fmt.Fprintf(&history, "%s:%s ", guess, pattern)
+	}
+	return game, nil
+}
+
+// nextGuess returns the guess to play after the given history.
+func (s *Solver) nextGuess(history string, candidates []string, first bool) string {
+	if first && s.Opener != "" {
+		return s.Opener
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	if guess, ok := s.next.Load(history); ok {
+		return guess.(string)
+	}
+	guess := s.Strategy.Rank(s.Guesses, candidates, 1)[0].Word
+	s.next.Store(history, guess)
+	return guess
+}
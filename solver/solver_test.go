@@ -0,0 +1,86 @@
+package solver_test
+
+import (
+	"testing"
+	"wordle/solver"
+	"wordle/wordle"
+)
+
+var answers = []string{
+	"abide", "baked", "baker", "bakes", "cakes", "cigar", "crane", "fakes",
+	"least", "makes", "rebut", "slate", "takes", "these", "tulip", "wakes",
+}
+
+func TestPlay(t *testing.T) {
+	for _, strategy := range wordle.Strategies() {
+		s := &solver.Solver{
+			Answers:  answers,
+			Guesses:  append([]string{"flick", "stamp"}, answers...),
+			Strategy: strategy,
+		}
+		for _, answer := range answers {
+			game, err := s.Play(answer)
+			if err != nil {
+				t.Fatalf("%s: Play(%s) error = %v", strategy.Name(), answer, err)
+			}
+			if !game.Solved() {
+				t.Fatalf("%s: Play(%s) = %+v, want solved", strategy.Name(), answer, game)
+			}
+			last := game.Turns[len(game.Turns)-1]
+			if last.Guess != answer || last.Remaining != 1 {
+				t.Errorf("%s: Play(%s) last turn = %+v", strategy.Name(), answer, last)
+			}
+			for i := 1; i < len(game.Turns); i++ {
+				if game.Turns[i].Remaining >= game.Turns[i-1].Remaining && i < len(game.Turns)-1 {
+					t.Errorf("%s: Play(%s) did not narrow on turn %d: %+v", strategy.Name(), answer, i+1, game.Turns)
+				}
+			}
+		}
+	}
+}
+
+func TestPlayOpener(t *testing.T) {
+	s := &solver.Solver{Answers: answers, Guesses: answers, Strategy: wordle.Entropy, Opener: "crane"}
+	game, err := s.Play("cakes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Turns[0].Guess != "crane" {
+		t.Errorf("first guess = %s, want crane", game.Turns[0].Guess)
+	}
+	if game.Turns[0].Pattern != wordle.Score("crane", "cakes") {
+		t.Errorf("first pattern = %s, want %s", game.Turns[0].Pattern, wordle.Score("crane", "cakes"))
+	}
+}
+
+func TestPlayMaxTurns(t *testing.T) {
+	s := &solver.Solver{Answers: answers, Guesses: answers, Strategy: wordle.Entropy, Opener: "tulip", MaxTurns: 1}
+	game, err := s.Play("cakes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(game.Turns) != 1 || game.Solved() {
+		t.Errorf("Play() = %+v, want one unsolved turn", game)
+	}
+}
+
+func TestPlayUnknownAnswer(t *testing.T) {
+	s := &solver.Solver{Answers: answers, Guesses: answers, Strategy: wordle.Entropy}
+	if _, err := s.Play("zzzzz"); err == nil {
+		t.Errorf("Play(zzzzz) error = nil, want error")
+	}
+}
+
+func TestPlayRunes(t *testing.T) {
+	words := []string{"nandu", "ñandu", "mango"}
+	s := &solver.Solver{Answers: words, Guesses: words, Strategy: wordle.Entropy, Opener: "ñandu"}
+	for _, answer := range words {
+		game, err := s.Play(answer)
+		if err != nil {
+			t.Fatalf("Play(%s) error = %v", answer, err)
+		}
+		if !game.Solved() {
+			t.Errorf("Play(%s) = %+v, want solved", answer, game)
+		}
+	}
+}
@@ -4,7 +4,7 @@
 cd /Users/sgries174@cable.comcast.com/repos/sjg/wordle
 
 echo "=== Building server ==="
-go build -o wordle-server cmd/server/main.go
+go build -o wordle-server ./cmd/server
 if [ $? -ne 0 ]; then
     echo "Build failed!"
     exit 1
@@ -13,8 +13,6 @@ echo "Build successful!"
 
 echo ""
 echo "=== Starting server in background ==="
-export WORDLE_DICTIONARY=./american-english
-export WORDLE_REMOVE=./words-to-remove
 export WORDLE_PORT=9090
 
 ./wordle-server > server.log 2>&1 &
@@ -14,6 +14,7 @@ func TestParams(t *testing.T) {
 		missed       string
 		lettersAt    []wordle.LetterAt
 		lettersNotAt []wordle.LettersNotAt
+		counts       []wordle.LetterCount
 	}{
 		"no args": {
 			args:    "",
@@ -23,6 +24,16 @@ func TestParams(t *testing.T) {
 			args:    "",
 			wantErr: true,
 		},
+		"too few positions": {
+			args:    "abc . .",
+			wantErr: true,
+		},
+		"no missed letters": {
+			args:      ". . . a . .",
+			wantErr:   false,
+			missed:    "",
+			lettersAt: []wordle.LetterAt{{Position: 2, Letter: 'a'}},
+		},
 		"get missed": {
 			args:         "abcd . . . . .",
 			wantErr:      false,
@@ -35,7 +46,7 @@ func TestParams(t *testing.T) {
 			wantErr: false,
 			missed:  "abc",
 			lettersNotAt: []wordle.LettersNotAt{
-				{Position: 0, Letters: []byte{'a', 'b'}},
+				{Position: 0, Letters: []rune{'a', 'b'}},
 			},
 			lettersAt: nil,
 		},
@@ -44,7 +55,7 @@ func TestParams(t *testing.T) {
 			wantErr: false,
 			missed:  "abc",
 			lettersNotAt: []wordle.LettersNotAt{
-				{Position: 0, Letters: []byte{'a', 'b'}},
+				{Position: 0, Letters: []rune{'a', 'b'}},
 			},
 			lettersAt: []wordle.LetterAt{
 				{Position: 1, Letter: 'c'},
@@ -55,30 +66,52 @@ func TestParams(t *testing.T) {
 			wantErr: false,
 			missed:  "ertios",
 			lettersNotAt: []wordle.LettersNotAt{
-				{Position: 0, Letters: []byte{'a', 'g'}},
-				{Position: 2, Letters: []byte{'a'}},
-				{Position: 3, Letters: []byte{'n'}},
+				{Position: 0, Letters: []rune{'a', 'g'}},
+				{Position: 2, Letters: []rune{'a'}},
+				{Position: 3, Letters: []rune{'n'}},
 			},
 			lettersAt: nil,
 		},
+		"letter counts": {
+			args:    "sp -e . d . . e=1 d>=1",
+			wantErr: false,
+			missed:  "sp",
+			lettersNotAt: []wordle.LettersNotAt{
+				{Position: 0, Letters: []rune{'e'}},
+			},
+			lettersAt: []wordle.LetterAt{
+				{Position: 2, Letter: 'd'},
+			},
+			counts: []wordle.LetterCount{
+				{Letter: 'e', Min: 1, Exact: true},
+				{Letter: 'd', Min: 1},
+			},
+		},
+		"bad letter count": {
+			args:    "sp -e . d . . e<1",
+			wantErr: true,
+		},
 	}
 	for name, test := range tests {
 		test := test
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			missed, lettersAt, lettersNotAt, err := usrcmd.ReadUserCommand(test.args)
+			c, err := usrcmd.ReadUserCommand(test.args)
 			if (err != nil) != test.wantErr {
 				t.Errorf("ReadArgs() error = %v, wantErr %v", err, test.wantErr)
 				return
 			}
-			if missed != test.missed {
-				t.Errorf("ReadArgs() missed = %v, want %v", missed, test.missed)
+			if c.Missed != test.missed {
+				t.Errorf("ReadArgs() missed = %v, want %v", c.Missed, test.missed)
+			}
+			if !reflect.DeepEqual(c.LettersAt, test.lettersAt) {
+				t.Errorf("ReadArgs() lettersAt = %v, want %v", c.LettersAt, test.lettersAt)
 			}
-			if !reflect.DeepEqual(lettersAt, test.lettersAt) {
-				t.Errorf("ReadArgs() lettersAt = %v, want %v", lettersAt, test.lettersAt)
+			if !reflect.DeepEqual(c.LettersNotAt, test.lettersNotAt) {
+				t.Errorf("ReadArgs() lettersNotAt = %v, want %v", c.LettersNotAt, test.lettersNotAt)
 			}
-			if !reflect.DeepEqual(lettersNotAt, test.lettersNotAt) {
-				t.Errorf("ReadArgs() lettersNotAt = %v, want %v", lettersNotAt, test.lettersNotAt)
+			if !reflect.DeepEqual(c.Counts, test.counts) {
+				t.Errorf("ReadArgs() counts = %v, want %v", c.Counts, test.counts)
 			}
 		})
 	}
@@ -0,0 +1,59 @@
+package usrcmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"wordle/wordle"
+)
+
+// IsGuess reports whether the line looks like a scored guess, such as
+// "crane xygxx", rather than a line of constraints.
+func IsGuess(s string) bool {
+	return len(strings.Fields(s)) == 2
+}
+
+// ReadGuess parses a guess followed by the pattern it was scored with, written
+// as letters ("crane xygxx": g green, y yellow, x gray) or as the emoji squares
+// from the NYT share text ("crane ⬛🟨🟩⬛⬛"). The guess may have letters from
+// any alphabet.
+func ReadGuess(s string) (wordle.Guess, error) {
+	args := strings.Fields(s)
+	if len(args) != 2 {
+		return wordle.Guess{}, fmt.Errorf("want a guess and its pattern, e.g. crane xygxx")
+	}
+	word := strings.ToLower(args[0])
+	n := wordle.Len(word)
+	if err := wordle.CheckLength(n); err != nil {
+		return wordle.Guess{}, fmt.Errorf("guess %q: %w", word, err)
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return wordle.Guess{}, fmt.Errorf("guess %q must only have letters", word)
+		}
+	}
+	pattern, err := wordle.ParsePattern(args[1])
+	if err != nil {
+		return wordle.Guess{}, err
+	}
+	if pattern.Len() != n {
+		return wordle.Guess{}, fmt.Errorf("pattern %q has %d tiles but %q has %d letters", args[1], pattern.Len(), word, n)
+	}
+	return wordle.Guess{Word: word, Pattern: pattern}, nil
+}
+
+// ReadGuesses parses one scored guess per line, skipping blank lines.
+func ReadGuesses(s string) ([]wordle.Guess, error) {
+	var guesses []wordle.Guess
+	for i, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		guess, err := ReadGuess(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		guesses = append(guesses, guess)
+	}
+	return guesses, nil
+}
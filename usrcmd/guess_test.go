@@ -0,0 +1,63 @@
+package usrcmd_test
+
+import (
+	"testing"
+	"wordle/usrcmd"
+	"wordle/wordle"
+)
+
+func TestReadGuess(t *testing.T) {
+	tests := map[string]struct {
+		line    string
+		wantErr bool
+		word    string
+		pattern string
+	}{
+		"letters":        {line: "crane xygxx", word: "crane", pattern: "xygxx"},
+		"emoji":          {line: "crane ⬛🟨🟩⬛⬛", word: "crane", pattern: "xygxx"},
+		"upper case":     {line: " CRANE XYGXX ", word: "crane", pattern: "xygxx"},
+		"short pattern":  {line: "crane xyg", wantErr: true},
+		"four letters":   {line: "word gxyx", word: "word", pattern: "gxyx"},
+		"seven letters":  {line: "lingoes xxxxxxg", word: "lingoes", pattern: "xxxxxxg"},
+		"accents":        {line: "ÑANDÚ gxxxy", word: "ñandú", pattern: "gxxxy"},
+		"short word":     {line: "cr xy", wantErr: true},
+		"not letters":    {line: "cr4ne xygxx", wantErr: true},
+		"bad pattern":    {line: "crane abcde", wantErr: true},
+		"no pattern":     {line: "crane", wantErr: true},
+		"constraints":    {line: "cne . -r a . .", wantErr: true},
+		"too many words": {line: "crane xygxx slate", wantErr: true},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := usrcmd.ReadGuess(test.line)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ReadGuess(%q) error = %v, wantErr %v", test.line, err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Word != test.word || got.Pattern.String() != test.pattern {
+				t.Errorf("ReadGuess(%q) = %s %s, want %s %s", test.line, got.Word, got.Pattern, test.word, test.pattern)
+			}
+		})
+	}
+}
+
+func TestReadGuesses(t *testing.T) {
+	guesses, err := usrcmd.ReadGuesses("crane xxyxg\n\nspeed xxyxy\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := wordle.NewConstraints(guesses...)
+	if want := "cnprs . . -ae -e e d>=1 e=1"; c.String() != want {
+		t.Errorf("constraints = %q, want %q", c, want)
+	}
+	if !wordle.CheckWord("abide", c) {
+		t.Errorf("constraints %q rule out the answer", c)
+	}
+	if _, err := usrcmd.ReadGuesses("crane xygxx\ncrane"); err == nil {
+		t.Errorf("ReadGuesses() with a bad line error = nil, want error")
+	}
+}
@@ -0,0 +1,19 @@
+// Package web holds the files the server serves as they are.
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed static
+var static embed.FS
+
+// Static returns the static files, served under /static/.
+func Static() fs.FS {
+	sub, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err) // the directory is embedded, so this can't happen
+	}
+	return sub
+}
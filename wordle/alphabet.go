@@ -0,0 +1,176 @@
+package wordle
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Alphabet is the set of letters words are spelled with, for playing Wordle
+// in other languages. Words are lowercased, and with Fold set a letter that
+// isn't in the alphabet is played as the alphabet letter it is an accented
+// form of, so "á" is played as "a". Letters in the alphabet are never folded:
+// Spanish keeps "ñ" but can fold "á".
+//
+// The zero Alphabet has every letter and folds none of them.
+type Alphabet struct {
+	Name    string
+	Letters string
+	Fold    bool
+}
+
+// The built-in alphabets. None of them fold; set Fold on a copy to play
+// accented letters as plain ones.
+var (
+	English    = Alphabet{Name: "english", Letters: "abcdefghijklmnopqrstuvwxyz"}
+	Spanish    = Alphabet{Name: "spanish", Letters: "abcdefghijklmnñopqrstuvwxyz"}
+	German     = Alphabet{Name: "german", Letters: "abcdefghijklmnopqrstuvwxyzäöüß"}
+	Portuguese = Alphabet{Name: "portuguese", Letters: "abcdefghijklmnopqrstuvwxyzç"}
+)
+
+// Alphabets returns the built-in alphabets, the default one first.
+func Alphabets() []Alphabet {
+	return []Alphabet{English, Spanish, German, Portuguese}
+}
+
+// AlphabetByName returns the built-in alphabet with the given name. An empty
+// name selects English.
+func AlphabetByName(name string) (Alphabet, error) {
+	if name == "" {
+		return English, nil
+	}
+	var names []string
+	for _, a := range Alphabets() {
+		if a.Name == strings.ToLower(name) {
+			return a, nil
+		}
+		names = append(names, a.Name)
+	}
+	return Alphabet{}, fmt.Errorf("unknown alphabet %q: want one of %s, or the letters themselves", name, strings.Join(names, ", "))
+}
+
+// ParseAlphabet returns the built-in alphabet named s, or else an alphabet of
+// the letters in s, such as "abcdefghijklmnopqrstuvwxyzåäö".
+func ParseAlphabet(s string) (Alphabet, error) {
+	a, err := AlphabetByName(s)
+	if err == nil {
+		return a, nil
+	}
+	letters := strings.ToLower(s)
+	seen := make(map[rune]bool)
+	for _, r := range letters {
+		if !unicode.IsLetter(r) || seen[r] {
+			return Alphabet{}, err
+		}
+		seen[r] = true
+	}
+	if len(seen) < 2 {
+		return Alphabet{}, err
+	}
+	return Alphabet{Letters: letters}, nil
+}
+
+// String returns the alphabet's name, or its letters if it has no name.
+func (a Alphabet) String() string {
+	switch {
+	case a.Name != "":
+		return a.Name
+	case a.Letters != "":
+		return a.Letters
+	}
+	return "any letters"
+}
+
+// Contains reports whether r is a letter of the alphabet.
+func (a Alphabet) Contains(r rune) bool {
+	if a.Letters == "" {
+		return unicode.IsLetter(r)
+	}
+	return strings.ContainsRune(a.Letters, r)
+}
+
+// Normalize returns word lowercased and, if the alphabet folds, with accented
+// letters replaced by the plain ones. It reports false if word has a letter
+// that isn't in the alphabet, or anything other than letters.
+func (a Alphabet) Normalize(word string) (string, bool) {
+	if word == "" {
+		return "", false
+	}
+	if a.hasASCII(word) {
+		return word, true
+	}
+	var sb strings.Builder
+	sb.Grow(len(word))
+	for _, r := range strings.ToLower(word) {
+		r, ok := a.letter(r)
+		if !ok {
+			return "", false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String(), true
+}
+
+// NormalizeText lowercases s and folds the letters in it, leaving everything
+// else, such as spaces, dots and emoji, as it is. It's for lines of user
+// input, which have more than words in them.
+func (a Alphabet) NormalizeText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			return r
+		}
+		r = unicode.ToLower(r)
+		if folded, ok := a.letter(r); ok {
+			return folded
+		}
+		return r
+	}, s)
+}
+
+// hasASCII reports whether word is made of lowercase ASCII letters that are
+// all in the alphabet, so it's already normalized. Most words in most word
+// lists are.
+func (a Alphabet) hasASCII(word string) bool {
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if c < 'a' || c > 'z' {
+			return false
+		}
+		if a.Letters != "" && strings.IndexByte(a.Letters, c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// letter returns the alphabet letter r is played as, folding it if need be.
+// r must be lowercase.
+func (a Alphabet) letter(r rune) (rune, bool) {
+	if a.Contains(r) {
+		return r, true
+	}
+	if !a.Fold {
+		return 0, false
+	}
+	base := fold(r)
+	if base != r && a.Contains(base) {
+		return base, true
+	}
+	return 0, false
+}
+
+// fold returns the letter r is an accented form of, such as "a" for "á", or r
+// if it has no accent.
+func fold(r rune) rune {
+	decomposed := norm.NFD.String(string(r))
+	base, size := utf8.DecodeRuneInString(decomposed)
+	for _, mark := range decomposed[size:] {
+		if !unicode.Is(unicode.Mn, mark) {
+			return r
+		}
+	}
+	return base
+}
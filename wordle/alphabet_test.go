@@ -0,0 +1,57 @@
+package wordle_test
+
+import (
+	"testing"
+	"wordle/wordle"
+)
+
+func TestNormalize(t *testing.T) {
+	folding := wordle.Spanish
+	folding.Fold = true
+	tests := []struct {
+		alphabet wordle.Alphabet
+		word     string
+		want     string
+		ok       bool
+	}{
+		{wordle.English, "Crane", "crane", true},
+		{wordle.English, "café", "", false},
+		{wordle.Spanish, "ñandú", "", false},
+		{folding, "ñandú", "ñandu", true},
+		{folding, "ÁRBOL", "arbol", true},
+		{folding, "straße", "", false},
+		{wordle.German, "straße", "straße", true},
+		{wordle.Alphabet{}, "ñandú", "ñandú", true},
+		{wordle.Alphabet{}, "r2d2", "", false},
+		{wordle.English, "", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.alphabet.Normalize(tt.word)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s Normalize(%q) = %q, %v, want %q, %v", tt.alphabet, tt.word, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if got, want := folding.NormalizeText("CAMIÓN ⬛🟨🟩⬛⬛ -ñ ."), "camion ⬛🟨🟩⬛⬛ -ñ ."; got != want {
+		t.Errorf("NormalizeText() = %q, want %q", got, want)
+	}
+}
+
+func TestParseAlphabet(t *testing.T) {
+	tests := map[string]string{
+		"":                              "abcdefghijklmnopqrstuvwxyz",
+		"Spanish":                       "abcdefghijklmnñopqrstuvwxyz",
+		"abcdefghijklmnopqrstuvwxyzåäö": "abcdefghijklmnopqrstuvwxyzåäö",
+	}
+	for s, want := range tests {
+		a, err := wordle.ParseAlphabet(s)
+		if err != nil || a.Letters != want {
+			t.Errorf("ParseAlphabet(%q) = %+v, %v, want letters %q", s, a, err, want)
+		}
+	}
+	for _, s := range []string{"klingon", "abca", "a"} {
+		if _, err := wordle.ParseAlphabet(s); err == nil {
+			t.Errorf("ParseAlphabet(%q) error = nil, want error", s)
+		}
+	}
+}
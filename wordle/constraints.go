@@ -0,0 +1,389 @@
+package wordle
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Guess is a word that was played and the pattern it was scored with.
+type Guess struct {
+	Word    string
+	Pattern Pattern
+}
+
+// Constraints is everything known about the answer: how long it is, the
+// letters that are not in it, the letters known to be at or not at each
+// position, and how many copies of a letter it has.
+//
+// The zero value has no constraints and matches every word. Constraints have a
+// text form, the same one the command line accepts, e.g. "cne . -r a . . e=1",
+// which is also how they are encoded as JSON.
+type Constraints struct {
+	// Length is the number of letters in the answer, or 0 if it isn't known,
+	// in which case words of any length match and the text form has
+	// WordLength positions.
+	Length       int
+	Missed       string
+	LettersAt    []LetterAt
+	LettersNotAt []LettersNotAt
+	Counts       []LetterCount
+}
+
+// NewConstraints builds the constraints implied by a sequence of scored guesses.
+func NewConstraints(guesses ...Guess) Constraints {
+	var c Constraints
+	for _, g := range guesses {
+		c = c.Merge(fromGuess(g))
+	}
+	return c
+}
+
+// fromGuess translates one scored guess into constraints. Greens pin a letter
+// to a position and yellows exclude it from one. A gray letter is missed unless
+// another copy of it in the same guess was green or yellow; then the gray copy
+// only tells us the exact number of copies and that it isn't at this position.
+func fromGuess(g Guess) Constraints {
+	word := []rune(g.Word)
+	c := Constraints{Length: len(word)}
+	found := make(map[rune]int)
+	grayed := make(map[rune]bool)
+	for i, letter := range word {
+		if g.Pattern.At(i) == Gray {
+			grayed[letter] = true
+		} else {
+			found[letter]++
+		}
+	}
+
+	for i, letter := range word {
+		switch {
+		case g.Pattern.At(i) == Green:
+			c.LettersAt = append(c.LettersAt, LetterAt{Position: i, Letter: letter})
+		case g.Pattern.At(i) == Yellow || found[letter] > 0:
+			c.LettersNotAt = append(c.LettersNotAt, LettersNotAt{Position: i, Letters: []rune{letter}})
+		case !strings.ContainsRune(c.Missed, letter):
+			c.Missed += string(letter)
+		}
+	}
+
+	for letter, n := range found {
+		if grayed[letter] || n > 1 {
+			c.Counts = append(c.Counts, LetterCount{Letter: letter, Min: n, Exact: grayed[letter]})
+		}
+	}
+	return c.normalize()
+}
+
+// Merge returns the constraints that hold when both c and other hold. If they
+// are for words of different lengths, the merged constraints are for the
+// longer words; callers that know the length should check Len.
+func (c Constraints) Merge(other Constraints) Constraints {
+	merged := Constraints{
+		Length:       max(c.Length, other.Length),
+		Missed:       c.Missed,
+		LettersAt:    slices.Clone(c.LettersAt),
+		LettersNotAt: slices.Clone(c.LettersNotAt),
+		Counts:       slices.Clone(c.Counts),
+	}
+	merged.Missed += other.Missed
+	merged.LettersAt = append(merged.LettersAt, other.LettersAt...)
+	merged.LettersNotAt = append(merged.LettersNotAt, other.LettersNotAt...)
+	merged.Counts = append(merged.Counts, other.Counts...)
+	return merged.normalize()
+}
+
+// normalize sorts the constraints and removes duplicates so that equivalent
+// constraints have the same text form. Conflicting entries are kept so that
+// Validate can report them.
+func (c Constraints) normalize() Constraints {
+	missed := []rune(c.Missed)
+	slices.Sort(missed)
+	c.Missed = string(slices.Compact(missed))
+
+	c.LettersAt = slices.Clone(c.LettersAt)
+	slices.SortFunc(c.LettersAt, func(a, b LetterAt) int {
+		if a.Position != b.Position {
+			return a.Position - b.Position
+		}
+		return int(a.Letter) - int(b.Letter)
+	})
+	c.LettersAt = slices.Compact(c.LettersAt)
+
+	byPosition := make(map[int][]rune)
+	for _, notAt := range c.LettersNotAt {
+		byPosition[notAt.Position] = append(byPosition[notAt.Position], notAt.Letters...)
+	}
+	c.LettersNotAt = nil
+	for position, letters := range byPosition {
+		slices.Sort(letters)
+		c.LettersNotAt = append(c.LettersNotAt, LettersNotAt{Position: position, Letters: slices.Compact(letters)})
+	}
+	slices.SortFunc(c.LettersNotAt, func(a, b LettersNotAt) int {
+		return a.Position - b.Position
+	})
+
+	c.Counts = slices.Clone(c.Counts)
+	slices.SortFunc(c.Counts, func(a, b LetterCount) int {
+		if a.Letter != b.Letter {
+			return int(a.Letter) - int(b.Letter)
+		}
+		return a.Min - b.Min
+	})
+	c.Counts = slices.Compact(c.Counts)
+	return c
+}
+
+// knownCounts returns, for every letter known to be in the word, the minimum
+// number of copies the constraints require.
+func (c Constraints) knownCounts() map[rune]int {
+	known := make(map[rune]int)
+	greens := make(map[LetterAt]bool)
+	for _, at := range c.LettersAt {
+		if !greens[at] {
+			greens[at] = true
+			known[at.Letter]++
+		}
+	}
+	for _, notAt := range c.LettersNotAt {
+		for _, letter := range notAt.Letters {
+			if known[letter] == 0 {
+				known[letter] = 1
+			}
+		}
+	}
+	for _, count := range c.Counts {
+		if count.Min > known[count.Letter] {
+			known[count.Letter] = count.Min
+		}
+	}
+	return known
+}
+
+// Len returns the number of letters in the answer: Length, or WordLength if
+// it isn't known.
+func (c Constraints) Len() int {
+	if c.Length == 0 {
+		return WordLength
+	}
+	return c.Length
+}
+
+// CheckLen reports an error if the constraints are for words that don't have
+// n letters. Constraints whose length isn't known fit words of any length.
+func (c Constraints) CheckLen(n int) error {
+	if c.Length != 0 && c.Length != n {
+		return fmt.Errorf("clues are for %d letter words, not %d", c.Length, n)
+	}
+	return nil
+}
+
+// HasClues reports whether the constraints know anything about the answer
+// besides its length.
+func (c Constraints) HasClues() bool {
+	return c.Missed != "" || len(c.LettersAt) > 0 || len(c.LettersNotAt) > 0 || len(c.Counts) > 0
+}
+
+// Validate reports the first contradiction in the constraints, such as two
+// different letters at the same position or more known letters than fit in
+// a word. Constraints that fail validation match no words.
+func (c Constraints) Validate() error {
+	length := c.Len()
+	if err := CheckLength(length); err != nil {
+		return err
+	}
+	greens := make(map[int]rune)
+	for _, at := range c.LettersAt {
+		if at.Position < 0 || at.Position >= length {
+			return fmt.Errorf("position %d is out of range", at.Position+1)
+		}
+		if letter, ok := greens[at.Position]; ok && letter != at.Letter {
+			return fmt.Errorf("position %d cannot be both %c and %c", at.Position+1, letter, at.Letter)
+		}
+		greens[at.Position] = at.Letter
+	}
+
+	for _, notAt := range c.LettersNotAt {
+		if notAt.Position < 0 || notAt.Position >= length {
+			return fmt.Errorf("position %d is out of range", notAt.Position+1)
+		}
+		for _, letter := range notAt.Letters {
+			if greens[notAt.Position] == letter {
+				return fmt.Errorf("%c cannot be both at and not at position %d", letter, notAt.Position+1)
+			}
+		}
+	}
+
+	exact := make(map[rune]int)
+	for _, count := range c.Counts {
+		if !count.Exact {
+			continue
+		}
+		if n, ok := exact[count.Letter]; ok && n != count.Min {
+			return fmt.Errorf("%c cannot occur exactly %d and exactly %d times", count.Letter, n, count.Min)
+		}
+		exact[count.Letter] = count.Min
+	}
+
+	total := 0
+	for letter, n := range c.knownCounts() {
+		if want, ok := exact[letter]; ok && n > want {
+			return fmt.Errorf("%c occurs at least %d times but exactly %d times", letter, n, want)
+		}
+		total += n
+	}
+	if total > length {
+		return fmt.Errorf("%d known letters do not fit in a %d letter word", total, length)
+	}
+	return nil
+}
+
+// String returns the text form of the constraints.
+func (c Constraints) String() string {
+	c = c.normalize()
+
+	parts := []string{c.Missed}
+	if c.Missed == "" {
+		parts[0] = "."
+	}
+	positions := make([]string, c.Len())
+	for i := range positions {
+		positions[i] = "."
+	}
+	for _, notAt := range c.LettersNotAt {
+		if notAt.Position >= 0 && notAt.Position < len(positions) {
+			positions[notAt.Position] = "-" + string(notAt.Letters)
+		}
+	}
+	var displaced []rune
+	for _, at := range c.LettersAt {
+		if at.Position < 0 || at.Position >= len(positions) {
+			continue
+		}
+		if letters, ok := strings.CutPrefix(positions[at.Position], "-"); ok {
+			displaced = append(displaced, []rune(letters)...)
+		}
+		positions[at.Position] = string(at.Letter)
+	}
+	// A known position has no room for the letters that are not at it, so
+	// keep only the fact that they are in the word, unless another position
+	// already says so.
+	for _, letter := range displaced {
+		if !strings.ContainsRune(strings.Join(positions, ""), letter) {
+			c.Counts = append(c.Counts, LetterCount{Letter: letter, Min: 1})
+		}
+	}
+	parts = append(parts, positions...)
+	for _, count := range c.normalize().Counts {
+		op := ">="
+		if count.Exact {
+			op = "="
+		}
+		parts = append(parts, fmt.Sprintf("%c%s%d", count.Letter, op, count.Min))
+	}
+	return strings.Join(parts, " ")
+}
+
+// MarshalText encodes the constraints in their text form.
+func (c Constraints) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes constraints from their text form.
+func (c *Constraints) UnmarshalText(text []byte) error {
+	parsed, err := ParseConstraints(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// ParseConstraints reads constraints in their text form: the missed letters,
+// then one token per position, then optional letter counts. A position is a
+// letter if it is known (green), "-" followed by letters that are in the word
+// but not at that position (yellow), or "." if nothing is known. A count is
+// "e=1" for exactly one e or "e>=2" for at least two. "." stands for no missed
+// letters. The number of positions is the length of the word. Letters may be
+// any Unicode letters; see Alphabet.
+//
+// When a position is both known and has letters that are not at it, the text
+// form keeps the known letter and records the others as "r>=1" counts.
+func ParseConstraints(s string) (Constraints, error) {
+	args := strings.Fields(s)
+	if len(args) == 0 {
+		return Constraints{}, fmt.Errorf("no arguments")
+	}
+	// The positions run up to the first letter count
+	length := len(args) - 1
+	for i, v := range args[1:] {
+		if !isPosition(v) {
+			length = i
+			break
+		}
+	}
+	if length < MinWordLength {
+		return Constraints{}, fmt.Errorf("not enough arguments")
+	}
+	if err := CheckLength(length); err != nil {
+		return Constraints{}, err
+	}
+
+	c := Constraints{Length: length}
+	if args[0] != "." {
+		c.Missed = args[0]
+	}
+	for i, v := range args[1 : length+1] {
+		if v == "." {
+			continue
+		}
+		if letters, ok := strings.CutPrefix(v, "-"); ok {
+			c.LettersNotAt = append(c.LettersNotAt, LettersNotAt{Position: i, Letters: []rune(letters)})
+		} else {
+			letter, _ := utf8.DecodeRuneInString(v)
+			c.LettersAt = append(c.LettersAt, LetterAt{Position: i, Letter: letter})
+		}
+	}
+	for _, v := range args[length+1:] {
+		count, err := parseCount(v)
+		if err != nil {
+			return Constraints{}, err
+		}
+		c.Counts = append(c.Counts, count)
+	}
+	return c, nil
+}
+
+// isPosition reports whether a token of the text form describes a position
+// rather than a letter count.
+func isPosition(s string) bool {
+	return utf8.RuneCountInString(s) == 1 || strings.HasPrefix(s, "-")
+}
+
+// parseCount parses a letter count: "e=1" means exactly one e and "e>=2" means
+// at least two.
+func parseCount(s string) (LetterCount, error) {
+	if utf8.RuneCountInString(s) < 3 {
+		return LetterCount{}, fmt.Errorf("invalid letter count %q", s)
+	}
+	letter, size := utf8.DecodeRuneInString(s)
+	count := LetterCount{Letter: letter}
+	rest := s[size:]
+	switch {
+	case strings.HasPrefix(rest, ">="):
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "="):
+		rest = rest[1:]
+		count.Exact = true
+	default:
+		return LetterCount{}, fmt.Errorf("invalid letter count %q: want e.g. e=1 or e>=2", s)
+	}
+	n, err := strconv.Atoi(rest)
+	if err != nil || n < 0 {
+		return LetterCount{}, fmt.Errorf("invalid letter count %q: want e.g. e=1 or e>=2", s)
+	}
+	count.Min = n
+	return count, nil
+}
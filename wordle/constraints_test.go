@@ -0,0 +1,225 @@
+package wordle_test
+
+import (
+	"encoding/json"
+	"testing"
+	"wordle/wordle"
+)
+
+var testWords = []string{
+	"abide", "cigar", "crane", "eerie", "geese", "hello", "least", "lolly",
+	"react", "rebut", "sissy", "slate", "speed", "these", "tulip", "humph",
+}
+
+func TestNewConstraints(t *testing.T) {
+	tests := map[string]struct {
+		guesses []wordle.Guess
+		want    string
+	}{
+		"none": {
+			want: ". . . . . .",
+		},
+		"one guess": {
+			guesses: []wordle.Guess{{Word: "crane", Pattern: wordle.Score("crane", "react")}},
+			want:    "n -c -r a . -e",
+		},
+		"repeated letter, one copy gray": {
+			guesses: []wordle.Guess{{Word: "speed", Pattern: wordle.Score("speed", "abide")}},
+			want:    "ps . . -e -e -d e=1",
+		},
+		"repeated letter, both copies found": {
+			guesses: []wordle.Guess{{Word: "geese", Pattern: wordle.Score("geese", "eerie")}},
+			want:    "gs . e -e . e e>=3",
+		},
+		"two guesses": {
+			guesses: []wordle.Guess{
+				{Word: "crane", Pattern: wordle.Score("crane", "rebut")},
+				{Word: "least", Pattern: wordle.Score("least", "rebut")},
+			},
+			want: "aclns . e . . t r>=1",
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := wordle.NewConstraints(test.guesses...)
+			if got.String() != test.want {
+				t.Errorf("NewConstraints() = %q, want %q", got, test.want)
+			}
+			if err := got.Validate(); err != nil {
+				t.Errorf("Validate() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+// TestConstraintsKeepAnswer checks that the constraints from scoring any guess
+// against an answer never rule that answer out, and that the constraints
+// survive a trip through their text form.
+func TestConstraintsKeepAnswer(t *testing.T) {
+	for _, answer := range testWords {
+		for _, guess := range testWords {
+			c := wordle.NewConstraints(wordle.Guess{Word: guess, Pattern: wordle.Score(guess, answer)})
+			if !wordle.CheckWord(answer, c) {
+				t.Errorf("%s scored against %s gives %q, which rules out the answer", guess, answer, c)
+			}
+			parsed, err := wordle.ParseConstraints(c.String())
+			if err != nil {
+				t.Fatalf("ParseConstraints(%q) error = %v", c, err)
+			}
+			for _, word := range testWords {
+				if wordle.CheckWord(word, c) != wordle.CheckWord(word, parsed) {
+					t.Errorf("%q and its text form disagree on %s", c, word)
+				}
+				// A word is consistent with the constraints exactly when it
+				// would have produced the same pattern.
+				same := wordle.Score(guess, word) == wordle.Score(guess, answer)
+				if wordle.CheckWord(word, c) != same {
+					t.Errorf("%q: CheckWord(%s) = %v, want %v", c, word, !same, same)
+				}
+			}
+		}
+	}
+}
+
+func TestConstraintsMerge(t *testing.T) {
+	a, err := wordle.ParseConstraints("cn . -r a . .")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := wordle.ParseConstraints("st -r . . . e e=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := a.Merge(b).String()
+	if want := "cnst -r -r a . e e=1"; got != want {
+		t.Errorf("Merge() = %q, want %q", got, want)
+	}
+}
+
+func TestConstraintsValidate(t *testing.T) {
+	tests := map[string]struct {
+		constraints string
+		wantErr     bool
+	}{
+		"empty":               {constraints: ". . . . . .", wantErr: false},
+		"consistent":          {constraints: "cne . -r a . .", wantErr: false},
+		"two letters at once": {constraints: ". a . . . . e=2 a>=1", wantErr: false},
+		"too many letters":    {constraints: ". -a -b -c -d -ef", wantErr: true},
+		"exact conflict":      {constraints: ". . . . . . e=1 e=2", wantErr: true},
+		"exact below greens":  {constraints: ". e e . . . e=1", wantErr: true},
+		"count too large":     {constraints: ". . . . . . e>=6", wantErr: true},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c, err := wordle.ParseConstraints(test.constraints)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Validate(); (err != nil) != test.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", test.constraints, err, test.wantErr)
+			}
+		})
+	}
+
+	conflict := wordle.Constraints{LettersAt: []wordle.LetterAt{{Position: 0, Letter: 'a'}, {Position: 0, Letter: 'b'}}}
+	if err := conflict.Validate(); err == nil {
+		t.Errorf("Validate() of two letters at one position = nil, want error")
+	}
+}
+
+func TestConstraintsJSON(t *testing.T) {
+	c := wordle.NewConstraints(wordle.Guess{Word: "speed", Pattern: wordle.Score("speed", "abide")})
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"ps . . -e -e -d e=1"`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+	var decoded wordle.Constraints
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != c.String() {
+		t.Errorf("json round trip = %q, want %q", decoded, c)
+	}
+}
+
+func TestConstraintsLength(t *testing.T) {
+	words := []string{"lingo", "lingos", "bingo", "bongos", "tango"}
+
+	c, err := wordle.ParseConstraints("t . i n g o . e=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Length != 6 || c.String() != "t . i n g o . e=0" {
+		t.Errorf("ParseConstraints() = %+v (%s), want 6 letters", c, c)
+	}
+	if got := wordle.MakePossibles(words, c); len(got) != 1 || got[0] != "lingos" {
+		t.Errorf("MakePossibles() = %v, want [lingos]", got)
+	}
+
+	c = wordle.NewConstraints(wordle.Guess{Word: "bongos", Pattern: wordle.Score("bongos", "lingos")})
+	if c.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", c.Len())
+	}
+	if got := wordle.MakePossibles(words, c); len(got) != 1 || got[0] != "lingos" {
+		t.Errorf("MakePossibles() = %v, want [lingos]", got)
+	}
+
+	// Without a length every word matches, but the text form has five positions
+	if got := wordle.MakePossibles(words, wordle.Constraints{}); len(got) != len(words) {
+		t.Errorf("MakePossibles() with no constraints = %v, want every word", got)
+	}
+
+	for _, s := range []string{". . .", ". . . . . . . . . . . .", "x . . a . e=1 ."} {
+		if _, err := wordle.ParseConstraints(s); err == nil {
+			t.Errorf("ParseConstraints(%q) error = nil, want error", s)
+		}
+	}
+}
+
+func TestConstraintsRunes(t *testing.T) {
+	words := []string{"ñandú", "nandu", "añejo", "señal", "niñez"}
+
+	c := wordle.NewConstraints(wordle.Guess{Word: "señor", Pattern: wordle.Score("señor", "añejo")})
+	if got, want := c.String(), "rs . -e -ñ -o ."; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := wordle.MakePossibles(words, c); len(got) != 1 || got[0] != "añejo" {
+		t.Errorf("MakePossibles() = %v, want [añejo]", got)
+	}
+
+	parsed, err := wordle.ParseConstraints(c.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != c.String() {
+		t.Errorf("ParseConstraints(%q) = %q", c, parsed)
+	}
+
+	c, err = wordle.ParseConstraints("e . . . . ú ñ>=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := wordle.MakePossibles(words, c); len(got) != 1 || got[0] != "ñandú" {
+		t.Errorf("MakePossibles() = %v, want [ñandú]", got)
+	}
+}
+
+func TestHasClues(t *testing.T) {
+	if (wordle.Constraints{Length: 6}).HasClues() {
+		t.Errorf("HasClues() with only a length = true, want false")
+	}
+	c, err := wordle.ParseConstraints("x . . . . .")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.HasClues() {
+		t.Errorf("%q HasClues() = false, want true", c)
+	}
+}
@@ -0,0 +1,89 @@
+package wordle
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Frequencies tells how common words are, in any unit such as occurrences
+// per million. A more common word is taken to be more likely to be the answer.
+// A nil Frequencies makes every word equally likely.
+type Frequencies map[string]float64
+
+// Likelihoods returns the chance that each word is the answer, in the same
+// order as words, assuming the answer is one of them. A word missing from f is
+// given the lowest frequency in f, so it is unlikely but still possible.
+func (f Frequencies) Likelihoods(words []string) []float64 {
+	if len(words) == 0 {
+		return nil
+	}
+	weights := make([]float64, len(words))
+	if f == nil {
+		for i := range weights {
+			weights[i] = 1 / float64(len(words))
+		}
+		return weights
+	}
+
+	floor := f.lowest()
+	var total float64
+	for i, word := range words {
+		w, ok := f[word]
+		if !ok || w <= 0 {
+			w = floor
+		}
+		weights[i] = w
+		total += w
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+	return weights
+}
+
+// lowest returns the lowest positive frequency in f, or 1 if it has none.
+func (f Frequencies) lowest() float64 {
+	lowest := 0.0
+	for _, w := range f {
+		if w > 0 && (lowest == 0 || w < lowest) {
+			lowest = w
+		}
+	}
+	if lowest == 0 {
+		return 1
+	}
+	return lowest
+}
+
+// SortByLikelihood sorts words in place, most common first. Words with the
+// same frequency, and every word when f is nil, keep their order, so sorting
+// the alphabetical result of MakePossibles breaks ties alphabetically.
+func SortByLikelihood(words []string, f Frequencies) {
+	if f == nil {
+		return
+	}
+	floor := f.lowest()
+	weight := func(word string) float64 {
+		if w, ok := f[word]; ok && w > 0 {
+			return w
+		}
+		return floor
+	}
+	slices.SortStableFunc(words, func(a, b string) int {
+		return cmp.Compare(weight(b), weight(a))
+	})
+}
+
+// WithPrior returns a strategy that ranks guesses like s, but weights each
+// candidate by how likely it is to be the answer according to f instead of
+// treating them all the same. Information and the expected words left are
+// then averaged over the likely answers, and among equally good guesses the
+// likelier answer comes first. A nil f returns s.
+func WithPrior(s Strategy, f Frequencies) Strategy {
+	bs, ok := s.(bucketStrategy)
+	if !ok || f == nil {
+		return s
+	}
+	bs.prior = f
+	return bs
+}
@@ -0,0 +1,91 @@
+package wordle_test
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+	"wordle/wordle"
+)
+
+var frequencies = wordle.Frequencies{"baker": 60, "baked": 30, "bakes": 10}
+
+func TestLikelihoods(t *testing.T) {
+	// babka isn't in the table, so it gets the lowest frequency
+	got := frequencies.Likelihoods([]string{"babka", "baked", "baker", "bakes"})
+	want := []float64{10.0 / 110, 30.0 / 110, 60.0 / 110, 10.0 / 110}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("Likelihoods() = %v, want %v", got, want)
+			break
+		}
+	}
+
+	uniform := wordle.Frequencies(nil).Likelihoods([]string{"baked", "baker"})
+	if !slices.Equal(uniform, []float64{0.5, 0.5}) {
+		t.Errorf("nil Likelihoods() = %v, want equal chances", uniform)
+	}
+}
+
+func TestSortByLikelihood(t *testing.T) {
+	words := []string{"aalii", "babka", "baked", "baker", "bakes"}
+	wordle.SortByLikelihood(words, frequencies)
+	want := []string{"baker", "baked", "aalii", "babka", "bakes"}
+	if !slices.Equal(words, want) {
+		t.Errorf("SortByLikelihood() = %v, want %v", words, want)
+	}
+
+	words = []string{"bakes", "baker"}
+	wordle.SortByLikelihood(words, nil)
+	if !slices.Equal(words, []string{"bakes", "baker"}) {
+		t.Errorf("SortByLikelihood(nil) = %v, want the order unchanged", words)
+	}
+}
+
+func TestWithPrior(t *testing.T) {
+	candidates := []string{"babka", "baked", "baker", "bakes"}
+	guesses := append([]string{"fluff"}, candidates...)
+
+	// Without a prior baked, baker and bakes tie and baked wins alphabetically
+	if got := wordle.Entropy.Rank(guesses, candidates, 1)[0]; got.Word != "baked" || got.Probability != 0.25 {
+		t.Errorf("Rank() best = %+v, want baked with probability 0.25", got)
+	}
+
+	strategy := wordle.WithPrior(wordle.Entropy, frequencies)
+	if strategy.Name() != wordle.Entropy.Name() {
+		t.Errorf("WithPrior() name = %s, want %s", strategy.Name(), wordle.Entropy.Name())
+	}
+	got := strategy.Rank(guesses, candidates, 0)
+	var order []string
+	byWord := make(map[string]wordle.Suggestion)
+	for _, s := range got {
+		order = append(order, s.Word)
+		byWord[s.Word] = s
+	}
+	if want := []string{"baker", "baked", "bakes", "babka", "fluff"}; !slices.Equal(order, want) {
+		t.Errorf("WithPrior() order = %v, want %v", order, want)
+	}
+
+	// baker puts baker, babka and {baked, bakes} in separate buckets, which
+	// now hold 60, 10 and 40 of the 110
+	baker := byWord["baker"]
+	h := func(p float64) float64 { return -p * math.Log2(p) }
+	want := h(60.0/110) + h(10.0/110) + h(40.0/110)
+	if math.Abs(baker.Entropy-want) > 1e-9 {
+		t.Errorf("baker entropy = %v, want %v", baker.Entropy, want)
+	}
+	if math.Abs(baker.ExpectedRemaining-150.0/110) > 1e-9 {
+		t.Errorf("baker expected remaining = %v, want %v", baker.ExpectedRemaining, 150.0/110)
+	}
+	if math.Abs(baker.Probability-60.0/110) > 1e-9 {
+		t.Errorf("baker probability = %v, want %v", baker.Probability, 60.0/110)
+	}
+	if fluff := byWord["fluff"]; fluff.Entropy != 0 || math.Abs(fluff.ExpectedRemaining-4) > 1e-9 {
+		t.Errorf("fluff = %+v, want 0 bits and 4 remaining", fluff)
+	}
+
+	withNil := wordle.WithPrior(wordle.Minimax, nil).Rank(guesses, candidates, 0)
+	if !reflect.DeepEqual(withNil, wordle.Minimax.Rank(guesses, candidates, 0)) {
+		t.Error("WithPrior(nil) changed the ranking")
+	}
+}
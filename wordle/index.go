@@ -0,0 +1,182 @@
+package wordle
+
+import "math/bits"
+
+// Index is a word list prepared for filtering by constraints. For every
+// position it has a bitmap of the words with each letter there, and for every
+// letter bitmaps of the words with at least one, two, ... copies of it, so
+// Possibles is a few bitwise ANDs per constraint rather than a check of every
+// word. An Index doesn't change once built and is safe for concurrent use.
+type Index struct {
+	words   []string
+	all     bitset
+	lengths map[int]bitset
+	// at[i][r] is the words with r at position i
+	at []map[rune]bitset
+	// atLeast[r][k] is the words with more than k copies of r, so
+	// atLeast[r][0] is the words r is in
+	atLeast map[rune][]bitset
+}
+
+// NewIndex indexes words, which must not be modified afterwards.
+func NewIndex(words []string) *Index {
+	x := &Index{
+		words:   words,
+		all:     newBitset(len(words)),
+		lengths: make(map[int]bitset),
+		atLeast: make(map[rune][]bitset),
+	}
+	var seen []rune
+	for i, word := range words {
+		x.all.set(i)
+		seen = seen[:0]
+		for _, r := range word {
+			if len(seen) == len(x.at) {
+				x.at = append(x.at, make(map[rune]bitset))
+			}
+			x.bits(x.at[len(seen)], r).set(i)
+
+			// This is copy number k+1 of r in the word
+			k := 0
+			for _, s := range seen {
+				if s == r {
+					k++
+				}
+			}
+			if k == len(x.atLeast[r]) {
+				x.atLeast[r] = append(x.atLeast[r], newBitset(len(words)))
+			}
+			x.atLeast[r][k].set(i)
+			seen = append(seen, r)
+		}
+		if x.lengths[len(seen)] == nil {
+			x.lengths[len(seen)] = newBitset(len(words))
+		}
+		x.lengths[len(seen)].set(i)
+	}
+	return x
+}
+
+// bits returns the bitmap for r in m, adding an empty one if there isn't one.
+func (x *Index) bits(m map[rune]bitset, r rune) bitset {
+	b, ok := m[r]
+	if !ok {
+		b = newBitset(len(x.words))
+		m[r] = b
+	}
+	return b
+}
+
+// Len returns the number of words in the index.
+func (x *Index) Len() int {
+	return len(x.words)
+}
+
+// Possibles returns the indexed words that fit the constraints, in the order
+// they were indexed. It returns the same words as MakePossibles.
+func (x *Index) Possibles(c Constraints) []string {
+	known := c.knownCounts()
+	match := x.all.clone()
+	if c.Length != 0 {
+		match.and(x.lengths[c.Length])
+	}
+
+	for _, letter := range c.Missed {
+		n, ok := known[letter]
+		if !ok {
+			match.andNot(x.withAtLeast(letter, 1))
+			continue
+		}
+		match.and(x.withAtLeast(letter, n))
+		match.andNot(x.withAtLeast(letter, n+1))
+	}
+
+	for _, at := range c.LettersAt {
+		match.and(x.withLetterAt(at.Position, at.Letter))
+	}
+
+	for _, notAt := range c.LettersNotAt {
+		for _, letter := range notAt.Letters {
+			match.and(x.withAtLeast(letter, 1))
+			match.andNot(x.withLetterAt(notAt.Position, letter))
+		}
+	}
+
+	for _, count := range c.Counts {
+		match.and(x.withAtLeast(count.Letter, count.Min))
+		if count.Exact {
+			match.andNot(x.withAtLeast(count.Letter, count.Min+1))
+		}
+	}
+	return x.wordsIn(match)
+}
+
+// withLetterAt returns the words with letter at position, or nil if there
+// are none.
+func (x *Index) withLetterAt(position int, letter rune) bitset {
+	if position < 0 || position >= len(x.at) {
+		return nil
+	}
+	return x.at[position][letter]
+}
+
+// withAtLeast returns the words with at least n copies of letter, or nil if
+// there are none.
+func (x *Index) withAtLeast(letter rune, n int) bitset {
+	if n <= 0 {
+		return x.all
+	}
+	counts := x.atLeast[letter]
+	if n > len(counts) {
+		return nil
+	}
+	return counts[n-1]
+}
+
+func (x *Index) wordsIn(b bitset) []string {
+	var words []string
+	for i, chunk := range b {
+		for chunk != 0 {
+			words = append(words, x.words[i*64+bits.TrailingZeros64(chunk)])
+			chunk &= chunk - 1
+		}
+	}
+	return words
+}
+
+// bitset is a set of word numbers, one bit per word. Every bitset in an Index
+// has room for all of its words, and nil is the empty set.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) clone() bitset {
+	return append(bitset(nil), b...)
+}
+
+// and removes the words that aren't in other.
+func (b bitset) and(other bitset) {
+	if other == nil {
+		clear(b)
+		return
+	}
+	for i := range b {
+		b[i] &= other[i]
+	}
+}
+
+// andNot removes the words that are in other.
+func (b bitset) andNot(other bitset) {
+	if other == nil {
+		return
+	}
+	for i := range b {
+		b[i] &^= other[i]
+	}
+}
@@ -0,0 +1,145 @@
+package wordle_test
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"wordle/dictionary"
+	"wordle/wordle"
+)
+
+// builtinWords returns the built-in answers and guesses, of every length.
+func builtinWords(tb testing.TB) (answers, guesses []string) {
+	tb.Helper()
+	lists, err := dictionary.CreateListsFrom(io.Discard,
+		dictionary.EmbeddedSource(dictionary.DefaultAnswers),
+		dictionary.EmbeddedSource(dictionary.DefaultGuesses),
+		dictionary.EmbeddedSource(dictionary.DefaultRemove),
+	)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return lists.Answers, lists.Guesses
+}
+
+// indexConstraints are clues of every kind, including ones no word fits.
+var indexConstraints = []string{
+	". . . . .",
+	"cne . -r a . . e=1",
+	"xyz . . . . .",
+	"aeiou . . . . .",
+	"s . . . . s",
+	"e -e . . -e . e=2",
+	"e . -e . . . e=1",
+	"l . . . . . l=3",
+	"ai l i n g o s",
+	"q . . . . .",
+	". . . . . . . . . . .",
+}
+
+var indexGuesses = []string{
+	"crane xygxx",
+	"speed gyxyx",
+	"geese xxgxy",
+	"lolly xgxxx",
+	"ñandú gxxyx",
+	"lingos gggggg",
+	"abc xxx",
+}
+
+func TestIndex(t *testing.T) {
+	_, guesses := builtinWords(t)
+	words := append(slices.Clone(guesses), "ñandú", "añejo", "señor")
+	index := wordle.NewIndex(words)
+	if index.Len() != len(words) {
+		t.Errorf("Len() = %d, want %d", index.Len(), len(words))
+	}
+
+	constraints := []wordle.Constraints{{}}
+	for _, s := range indexConstraints {
+		c, err := wordle.ParseConstraints(s)
+		if err != nil {
+			t.Fatalf("ParseConstraints(%q): %v", s, err)
+		}
+		constraints = append(constraints, c, wordle.Constraints{Length: c.Len(), LettersAt: c.LettersAt}, c.Merge(wordle.Constraints{Length: 6}))
+	}
+	for _, s := range indexGuesses {
+		g := mustGuess(t, s)
+		constraints = append(constraints, wordle.NewConstraints(g))
+	}
+	constraints = append(constraints, wordle.Constraints{
+		LettersAt: []wordle.LetterAt{{Position: 20, Letter: 'a'}},
+		Counts:    []wordle.LetterCount{{Letter: 'z', Min: 0, Exact: true}},
+	})
+
+	for _, c := range constraints {
+		got := index.Possibles(c)
+		want := wordle.MakePossibles(words, c)
+		if !slices.Equal(got, want) {
+			t.Errorf("Possibles(%q) has %d words, want the %d from MakePossibles", c, len(got), len(want))
+		}
+	}
+
+	if got := wordle.NewIndex(nil).Possibles(wordle.Constraints{}); got != nil {
+		t.Errorf("empty index Possibles() = %v, want none", got)
+	}
+}
+
+func mustGuess(tb testing.TB, s string) wordle.Guess {
+	tb.Helper()
+	word, pattern, _ := strings.Cut(s, " ")
+	p, err := wordle.ParsePattern(pattern)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return wordle.Guess{Word: word, Pattern: p}
+}
+
+// benchmarkConstraints are the clues from a typical game: a few guesses in,
+// and the first guess alone, which leaves the most words.
+var benchmarkConstraints = []string{"crane xygxx", "crane xygxx\nsoapy xxgxx\nbrash xggxx"}
+
+func BenchmarkMakePossibles(b *testing.B) {
+	answers, _ := builtinWords(b)
+	answers = wordle.NewIndex(answers).Possibles(wordle.Constraints{Length: wordle.WordLength})
+	for _, c := range benchmarkGames(b) {
+		b.Run(c.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				wordle.MakePossibles(answers, c)
+			}
+		})
+	}
+}
+
+func BenchmarkIndexPossibles(b *testing.B) {
+	answers, _ := builtinWords(b)
+	answers = wordle.NewIndex(answers).Possibles(wordle.Constraints{Length: wordle.WordLength})
+	index := wordle.NewIndex(answers)
+	for _, c := range benchmarkGames(b) {
+		b.Run(c.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				index.Possibles(c)
+			}
+		})
+	}
+}
+
+func BenchmarkNewIndex(b *testing.B) {
+	answers, _ := builtinWords(b)
+	for i := 0; i < b.N; i++ {
+		wordle.NewIndex(answers)
+	}
+}
+
+func benchmarkGames(b *testing.B) []wordle.Constraints {
+	var games []wordle.Constraints
+	for _, game := range benchmarkConstraints {
+		c := wordle.Constraints{Length: wordle.WordLength}
+		for _, line := range strings.Split(game, "\n") {
+			c = c.Merge(wordle.NewConstraints(mustGuess(b, line)))
+		}
+		games = append(games, c)
+	}
+	return games
+}
@@ -0,0 +1,278 @@
+package wordle
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Suggestion is a possible next guess and how well it splits the candidates.
+type Suggestion struct {
+	Word string
+	// Entropy is the expected information, in bits, the guess's pattern gives.
+	Entropy float64
+	// ExpectedRemaining is the expected number of candidates left after the guess.
+	ExpectedRemaining float64
+	// WorstCase is the number of candidates left after the guess's least
+	// informative pattern.
+	WorstCase int
+	// Candidate is true when the guess could itself be the answer.
+	Candidate bool
+	// Probability is the chance that the guess is the answer.
+	Probability float64
+}
+
+// Strategy ranks the possible next guesses against the remaining candidates.
+type Strategy interface {
+	// Name is how the strategy is selected on the command line and in forms.
+	Name() string
+	// Rank scores every guess against the candidates and returns the best n,
+	// best first. A non-positive n returns every guess. Guesses must be the
+	// same length as the candidates.
+	Rank(guesses, candidates []string, n int) []Suggestion
+}
+
+var (
+	// Entropy picks the guess whose pattern gives the most information on
+	// average, measured as the Shannon entropy of the pattern distribution.
+	Entropy Strategy = bucketStrategy{name: "entropy", compare: byEntropy}
+	// Minimax picks the guess whose worst pattern leaves the fewest
+	// candidates, as in Knuth's Mastermind algorithm.
+	Minimax Strategy = bucketStrategy{name: "minimax", compare: byWorstCase}
+	// ExpectedSize picks the guess that leaves the fewest candidates on average.
+	ExpectedSize Strategy = bucketStrategy{name: "expected", compare: byExpectedRemaining}
+)
+
+// Strategies returns every available strategy, the default one first.
+func Strategies() []Strategy {
+	return []Strategy{Entropy, Minimax, ExpectedSize}
+}
+
+// StrategyByName returns the strategy with the given name. An empty name
+// selects the default strategy.
+func StrategyByName(name string) (Strategy, error) {
+	if name == "" {
+		return Entropy, nil
+	}
+	var names []string
+	for _, s := range Strategies() {
+		if s.Name() == name {
+			return s, nil
+		}
+		names = append(names, s.Name())
+	}
+	return nil, fmt.Errorf("unknown strategy %q: want one of %s", name, strings.Join(names, ", "))
+}
+
+// bucketStrategy ranks guesses by a statistic of how they partition the
+// candidates into pattern buckets. Ties go to guesses that could be the
+// answer, then to the likeliest answer, then to the alphabetically first word.
+type bucketStrategy struct {
+	name    string
+	compare func(a, b Suggestion) int
+	// prior weights the candidates; nil weights them all the same
+	prior Frequencies
+}
+
+func (s bucketStrategy) Name() string {
+	return s.name
+}
+
+func (s bucketStrategy) Rank(guesses, candidates []string, n int) []Suggestion {
+	var weights []float64
+	if s.prior != nil {
+		weights = s.prior.Likelihoods(candidates)
+	}
+	suggestions := scoreGuesses(guesses, candidates, weights)
+	s.sort(suggestions)
+	if n > 0 && n < len(suggestions) {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
+
+func (s bucketStrategy) sort(suggestions []Suggestion) {
+	slices.SortFunc(suggestions, func(a, b Suggestion) int {
+		if c := s.compare(a, b); c != 0 {
+			return c
+		}
+		switch {
+		case a.Candidate != b.Candidate:
+			if a.Candidate {
+				return -1
+			}
+			return 1
+		case a.Probability != b.Probability:
+			return cmp.Compare(b.Probability, a.Probability)
+		case a.Word < b.Word:
+			return -1
+		case a.Word > b.Word:
+			return 1
+		}
+		return 0
+	})
+}
+
+func byEntropy(a, b Suggestion) int {
+	return cmp.Compare(b.Entropy, a.Entropy)
+}
+
+func byWorstCase(a, b Suggestion) int {
+	if c := cmp.Compare(a.WorstCase, b.WorstCase); c != 0 {
+		return c
+	}
+	return byExpectedRemaining(a, b)
+}
+
+func byExpectedRemaining(a, b Suggestion) int {
+	return cmp.Compare(a.ExpectedRemaining, b.ExpectedRemaining)
+}
+
+// scoreGuesses partitions the candidates by every guess and returns the
+// statistics for each, in the same order as guesses. weights holds the chance
+// that each candidate is the answer; nil means they're all equally likely.
+func scoreGuesses(guesses, candidates []string, weights []float64) []Suggestion {
+	if len(candidates) == 0 || len(guesses) == 0 {
+		return nil
+	}
+
+	probability := make(map[string]float64, len(candidates))
+	for i, word := range candidates {
+		if weights != nil {
+			probability[word] = weights[i]
+		} else {
+			probability[word] = 1 / float64(len(candidates))
+		}
+	}
+
+	// Words of ASCII letters can skip the check Score makes for them, which
+	// matters when every guess is scored against every candidate
+	score := Score
+	if n := len(candidates[0]); allASCII(guesses, n) && allASCII(candidates, n) {
+		score = scoreASCII
+	}
+
+	suggestions := make([]Suggestion, len(guesses))
+	forEachChunk(len(guesses), func(start, end int) {
+		buckets := make([]int, pow3(Len(candidates[0])))
+		var mass []float64
+		if weights != nil {
+			mass = make([]float64, len(buckets))
+		}
+		for i := start; i < end; i++ {
+			p, isCandidate := probability[guesses[i]]
+			s := Suggestion{
+				Word:        guesses[i],
+				Candidate:   isCandidate,
+				Probability: p,
+			}
+			if weights == nil {
+				partition(score, guesses[i], candidates, buckets)
+				s.Entropy = entropy(buckets, len(candidates))
+				s.ExpectedRemaining = expectedRemaining(buckets, len(candidates))
+			} else {
+				partitionWeighted(score, guesses[i], candidates, weights, buckets, mass)
+				s.Entropy = weightedEntropy(mass)
+				s.ExpectedRemaining = weightedExpectedRemaining(buckets, mass)
+			}
+			s.WorstCase = slices.Max(buckets)
+			suggestions[i] = s
+		}
+	})
+	return suggestions
+}
+
+// partition counts how many candidates fall into each pattern bucket for the
+// guess, scoring them with score. buckets is cleared first and must have room
+// for every pattern.
+func partition(score func(guess, answer string) Pattern, guess string, candidates []string, buckets []int) {
+	clear(buckets)
+	for _, answer := range candidates {
+		buckets[score(guess, answer).Index()]++
+	}
+}
+
+// partitionWeighted is partition that also adds up the weight of the
+// candidates in each bucket in mass, which must be the same size as buckets.
+func partitionWeighted(score func(guess, answer string) Pattern, guess string, candidates []string, weights []float64, buckets []int, mass []float64) {
+	clear(buckets)
+	clear(mass)
+	for i, answer := range candidates {
+		b := score(guess, answer).Index()
+		buckets[b]++
+		mass[b] += weights[i]
+	}
+}
+
+func entropy(buckets []int, total int) float64 {
+	var h float64
+	for _, n := range buckets {
+		if n > 0 {
+			p := float64(n) / float64(total)
+			h -= p * math.Log2(p)
+		}
+	}
+	return h
+}
+
+// weightedEntropy is entropy where mass holds the chance of each pattern.
+func weightedEntropy(mass []float64) float64 {
+	var h float64
+	for _, p := range mass {
+		if p > 0 {
+			h -= p * math.Log2(p)
+		}
+	}
+	return h
+}
+
+// weightedExpectedRemaining is the number of candidates left after the guess,
+// averaged over the chance of each pattern.
+func weightedExpectedRemaining(buckets []int, mass []float64) float64 {
+	var sum float64
+	for b, n := range buckets {
+		sum += mass[b] * float64(n)
+	}
+	return sum
+}
+
+func expectedRemaining(buckets []int, total int) float64 {
+	var sum float64
+	for _, n := range buckets {
+		sum += float64(n) * float64(n)
+	}
+	return sum / float64(total)
+}
+
+func pow3(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 3
+	}
+	return p
+}
+
+// forEachChunk splits [0, n) into one chunk per CPU and calls f on each chunk
+// concurrently, returning when they have all finished.
+func forEachChunk(n int, f func(start, end int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	size := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += size {
+		end := min(start+size, n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f(start, end)
+		}()
+	}
+	wg.Wait()
+}
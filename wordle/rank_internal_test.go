@@ -0,0 +1,29 @@
+package wordle
+
+import "testing"
+
+func TestStrategyOrder(t *testing.T) {
+	suggestions := []Suggestion{
+		{Word: "aaaaa", Entropy: 2.0, ExpectedRemaining: 2.5, WorstCase: 4},
+		{Word: "bbbbb", Entropy: 1.5, ExpectedRemaining: 2.75, WorstCase: 3},
+		{Word: "ccccc", Entropy: 1.9, ExpectedRemaining: 2.4, WorstCase: 4},
+		{Word: "ddddd", Entropy: 1.5, ExpectedRemaining: 2.75, WorstCase: 3, Candidate: true},
+	}
+	tests := map[string]struct {
+		strategy bucketStrategy
+		want     []string
+	}{
+		"entropy":  {strategy: Entropy.(bucketStrategy), want: []string{"aaaaa", "ccccc", "ddddd", "bbbbb"}},
+		"minimax":  {strategy: Minimax.(bucketStrategy), want: []string{"ddddd", "bbbbb", "ccccc", "aaaaa"}},
+		"expected": {strategy: ExpectedSize.(bucketStrategy), want: []string{"ccccc", "aaaaa", "ddddd", "bbbbb"}},
+	}
+	for name, test := range tests {
+		sorted := append([]Suggestion(nil), suggestions...)
+		test.strategy.sort(sorted)
+		for i, s := range sorted {
+			if s.Word != test.want[i] {
+				t.Fatalf(`%s: position %d is %s, want %s`, name, i, s.Word, test.want[i])
+			}
+		}
+	}
+}
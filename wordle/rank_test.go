@@ -0,0 +1,102 @@
+package wordle_test
+
+import (
+	"math"
+	"testing"
+	"wordle/wordle"
+)
+
+func TestRank(t *testing.T) {
+	candidates := []string{"babka", "baked", "baker", "bakes"}
+	guesses := append([]string{"dross", "fluff"}, candidates...)
+
+	got := wordle.Entropy.Rank(guesses, candidates, 0)
+	if len(got) != len(guesses) {
+		t.Fatalf("Rank() returned %d suggestions, want %d", len(got), len(guesses))
+	}
+
+	// "dross" puts each candidate in its own bucket: d, r and s tell them apart.
+	best := got[0]
+	if best.Word != "dross" {
+		t.Errorf("best guess = %s, want dross", best.Word)
+	}
+	if math.Abs(best.Entropy-2) > 1e-9 {
+		t.Errorf("dross entropy = %v, want 2", best.Entropy)
+	}
+	if best.ExpectedRemaining != 1 {
+		t.Errorf("dross expected remaining = %v, want 1", best.ExpectedRemaining)
+	}
+	if best.Candidate {
+		t.Errorf("dross is marked as a candidate")
+	}
+
+	// "fluff" tells us nothing.
+	last := got[len(got)-1]
+	if last.Word != "fluff" || last.Entropy != 0 || last.ExpectedRemaining != 4 {
+		t.Errorf("worst guess = %+v, want fluff with 0 bits and 4 remaining", last)
+	}
+
+	top := wordle.Entropy.Rank(guesses, candidates, 2)
+	if len(top) != 2 || top[0].Word != "dross" {
+		t.Errorf("Rank(n=2) = %+v, want dross first of 2", top)
+	}
+}
+
+func TestRankGuessesPrefersCandidates(t *testing.T) {
+	got := wordle.Entropy.Rank([]string{"aaaaa", "crane", "zzzzz"}, []string{"crane"}, 1)
+	if len(got) != 1 || got[0].Word != "crane" || !got[0].Candidate {
+		t.Errorf("Rank() = %+v, want crane", got)
+	}
+	if got := wordle.Entropy.Rank([]string{"crane"}, nil, 1); got != nil {
+		t.Errorf("Rank() with no candidates = %+v, want nil", got)
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	candidates := []string{"babka", "baked", "baker", "bakes", "cakes", "fakes", "makes", "takes"}
+	guesses := append([]string{"dross", "flick", "maker", "stamp"}, candidates...)
+
+	for _, strategy := range wordle.Strategies() {
+		strategy := strategy
+		t.Run(strategy.Name(), func(t *testing.T) {
+			t.Parallel()
+			all := strategy.Rank(guesses, candidates, 0)
+			if len(all) != len(guesses) {
+				t.Fatalf("Rank() returned %d suggestions, want %d", len(all), len(guesses))
+			}
+			best := all[0]
+			for _, s := range all {
+				if s.WorstCase < 1 || s.WorstCase > len(candidates) {
+					t.Errorf("%s worst case = %d, want 1..%d", s.Word, s.WorstCase, len(candidates))
+				}
+				var better bool
+				switch strategy.Name() {
+				case "entropy":
+					better = s.Entropy > best.Entropy
+				case "minimax":
+					better = s.WorstCase < best.WorstCase
+				case "expected":
+					better = s.ExpectedRemaining < best.ExpectedRemaining
+				}
+				if better {
+					t.Errorf("%s ranked %s first but %s is better", strategy.Name(), best.Word, s.Word)
+				}
+			}
+		})
+	}
+}
+
+func TestStrategyByName(t *testing.T) {
+	for _, s := range wordle.Strategies() {
+		got, err := wordle.StrategyByName(s.Name())
+		if err != nil || got.Name() != s.Name() {
+			t.Errorf("StrategyByName(%q) = %v, %v", s.Name(), got, err)
+		}
+	}
+	if got, err := wordle.StrategyByName(""); err != nil || got.Name() != wordle.Entropy.Name() {
+		t.Errorf("StrategyByName(\"\") = %v, %v, want entropy", got, err)
+	}
+	if _, err := wordle.StrategyByName("bogus"); err == nil {
+		t.Errorf("StrategyByName(\"bogus\") error = nil, want error")
+	}
+}
@@ -0,0 +1,238 @@
+package wordle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Feedback is the color of a single tile.
+type Feedback uint8
+
+const (
+	Gray   Feedback = iota // letter is not in the word (or not any more times)
+	Yellow                 // letter is in the word but not at this position
+	Green                  // letter is at this position
+)
+
+// MaxPatternLength is the longest word a Pattern can describe.
+const MaxPatternLength = 15
+
+const (
+	patternLengthShift = 24
+	patternCodeMask    = 1<<patternLengthShift - 1
+)
+
+// Pattern is the row of tile colors a guess produces against an answer.
+// The tiles are packed as base-3 digits with position 0 in the lowest digit,
+// and the word length is kept in the top byte.
+type Pattern uint32
+
+// NewPattern packs the tiles into a Pattern.
+func NewPattern(tiles ...Feedback) Pattern {
+	if len(tiles) > MaxPatternLength {
+		panic(fmt.Sprintf("wordle: pattern of length %d is too long", len(tiles)))
+	}
+	code := 0
+	for i := len(tiles) - 1; i >= 0; i-- {
+		code = code*3 + int(tiles[i])
+	}
+	return Pattern(len(tiles)<<patternLengthShift | code)
+}
+
+// Len returns the number of tiles in the pattern.
+func (p Pattern) Len() int {
+	return int(p >> patternLengthShift)
+}
+
+// Index returns the packed tile colors without the length. It is always less
+// than 3^Len, so it can be used to index a slice of pattern buckets.
+func (p Pattern) Index() int {
+	return int(p & patternCodeMask)
+}
+
+// At returns the color of the tile at position i (0-based).
+func (p Pattern) At(i int) Feedback {
+	code := p.Index()
+	for ; i > 0; i-- {
+		code /= 3
+	}
+	return Feedback(code % 3)
+}
+
+// Tiles unpacks the pattern into one Feedback per position.
+func (p Pattern) Tiles() []Feedback {
+	tiles := make([]Feedback, p.Len())
+	code := p.Index()
+	for i := range tiles {
+		tiles[i] = Feedback(code % 3)
+		code /= 3
+	}
+	return tiles
+}
+
+// Solved reports whether every tile is green.
+func (p Pattern) Solved() bool {
+	for _, f := range p.Tiles() {
+		if f != Green {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the pattern with one letter per tile: g for green, y for
+// yellow and x for gray.
+func (p Pattern) String() string {
+	var sb strings.Builder
+	for _, f := range p.Tiles() {
+		switch f {
+		case Green:
+			sb.WriteByte('g')
+		case Yellow:
+			sb.WriteByte('y')
+		default:
+			sb.WriteByte('x')
+		}
+	}
+	return sb.String()
+}
+
+// Emoji renders the pattern the way the NYT share text does.
+func (p Pattern) Emoji() string {
+	var sb strings.Builder
+	for _, f := range p.Tiles() {
+		switch f {
+		case Green:
+			sb.WriteString("🟩")
+		case Yellow:
+			sb.WriteString("🟨")
+		default:
+			sb.WriteString("⬛")
+		}
+	}
+	return sb.String()
+}
+
+// ParsePattern reads a pattern written one tile at a time, either as letters
+// (g for green, y for yellow, x or . for gray, in either case) or as the
+// emoji squares from the NYT share text, including the high contrast colors.
+func ParsePattern(s string) (Pattern, error) {
+	var tiles []Feedback
+	for _, r := range s {
+		switch r {
+		case 'g', 'G', '🟩', '🟧':
+			tiles = append(tiles, Green)
+		case 'y', 'Y', '🟨', '🟦':
+			tiles = append(tiles, Yellow)
+		case 'x', 'X', '.', '⬛', '⬜':
+			tiles = append(tiles, Gray)
+		case '\ufe0f':
+			// Variation selector that some platforms add after emoji
+		default:
+			return 0, fmt.Errorf("invalid tile %q in pattern %q: want g, y or x", r, s)
+		}
+	}
+	if len(tiles) == 0 {
+		return 0, fmt.Errorf("empty pattern")
+	}
+	if len(tiles) > MaxPatternLength {
+		return 0, fmt.Errorf("pattern %q is longer than %d tiles", s, MaxPatternLength)
+	}
+	return NewPattern(tiles...), nil
+}
+
+// Score computes the pattern that guess produces against answer. Greens are
+// assigned first; the remaining letters are then marked yellow from left to
+// right only while the answer still has unmatched copies of that letter, so a
+// repeated letter in the guess is gray once the answer's copies run out.
+//
+// guess and answer must have the same number of letters.
+func Score(guess, answer string) Pattern {
+	if isASCII(guess) && isASCII(answer) {
+		if len(guess) != len(answer) {
+			panic(fmt.Sprintf("wordle: cannot score %q against %q: lengths differ", guess, answer))
+		}
+		return scoreASCII(guess, answer)
+	}
+	return scoreRunes(guess, answer)
+}
+
+// scoreASCII is Score for words of ASCII letters, which are scored a byte at
+// a time since ranking guesses scores every guess against every candidate.
+func scoreASCII(guess, answer string) Pattern {
+	var tiles [MaxPatternLength]Feedback
+	var unmatched [256]uint8
+	for i := 0; i < len(guess); i++ {
+		if guess[i] == answer[i] {
+			tiles[i] = Green
+		} else {
+			unmatched[answer[i]]++
+		}
+	}
+	for i := 0; i < len(guess); i++ {
+		if tiles[i] == Green {
+			continue
+		}
+		if unmatched[guess[i]] > 0 {
+			tiles[i] = Yellow
+			unmatched[guess[i]]--
+		}
+	}
+	return NewPattern(tiles[:len(guess)]...)
+}
+
+// allASCII reports whether every word is n ASCII letters long, so the words
+// can be scored with scoreASCII.
+func allASCII(words []string, n int) bool {
+	for _, w := range words {
+		if len(w) != n || !isASCII(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// scoreRunes is Score for words with letters outside ASCII. A yellow uses up
+// the first unmatched copy of its letter in the answer.
+func scoreRunes(guess, answer string) Pattern {
+	var g, a [MaxPatternLength]rune
+	n, m := decodeLetters(g[:], guess), decodeLetters(a[:], answer)
+	if n != m || n > MaxPatternLength {
+		panic(fmt.Sprintf("wordle: cannot score %q against %q: lengths differ", guess, answer))
+	}
+
+	var tiles [MaxPatternLength]Feedback
+	var matched [MaxPatternLength]bool
+	for i := 0; i < n; i++ {
+		if g[i] == a[i] {
+			tiles[i] = Green
+			matched[i] = true
+		}
+	}
+	for i := 0; i < n; i++ {
+		if tiles[i] == Green {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if !matched[j] && a[j] == g[i] {
+				tiles[i] = Yellow
+				matched[j] = true
+				break
+			}
+		}
+	}
+	return NewPattern(tiles[:n]...)
+}
+
+// decodeLetters copies the letters of word into buf and returns how many
+// there are, which may be more than fit.
+func decodeLetters(buf []rune, word string) int {
+	n := 0
+	for _, r := range word {
+		if n < len(buf) {
+			buf[n] = r
+		}
+		n++
+	}
+	return n
+}
@@ -0,0 +1,102 @@
+package wordle_test
+
+import (
+	"testing"
+	"wordle/wordle"
+)
+
+func TestScore(t *testing.T) {
+	tests := map[string]struct {
+		guess  string
+		answer string
+		want   string
+	}{
+		"solved":                       {guess: "crane", answer: "crane", want: "ggggg"},
+		"no letters":                   {guess: "crane", answer: "tulip", want: "xxxxx"},
+		"mixed":                        {guess: "crane", answer: "react", want: "yygxy"},
+		"second e gray":                {guess: "speed", answer: "abide", want: "xxyxy"},
+		"green takes the only copy":    {guess: "eerie", answer: "crane", want: "xxyxg"},
+		"yellow goes to the first one": {guess: "eerie", answer: "these", want: "yxxxg"},
+		"greens before yellows":        {guess: "lolly", answer: "hello", want: "xyggx"},
+		"extra copy after greens":      {guess: "geese", answer: "these", want: "xxggg"},
+		"lengths differ":               {guess: "eerie", answer: "thee"},
+		"runes":                        {guess: "ñandú", answer: "andén", want: "xyyyx"},
+		"rune greens":                  {guess: "señor", answer: "señal", want: "gggxx"},
+		"rune copies":                  {guess: "ñoñez", answer: "niñez", want: "xxggg"},
+		"runes and ascii":              {guess: "crane", answer: "ñandú", want: "xxyyx"},
+		"rune lengths differ":          {guess: "ñandú", answer: "nandus"},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if wordle.Len(test.guess) != wordle.Len(test.answer) {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Score(%q, %q) did not panic", test.guess, test.answer)
+					}
+				}()
+			}
+			got := wordle.Score(test.guess, test.answer)
+			if got.String() != test.want {
+				t.Errorf("Score(%q, %q) = %s, want %s", test.guess, test.answer, got, test.want)
+			}
+		})
+	}
+}
+
+func TestPattern(t *testing.T) {
+	p := wordle.NewPattern(wordle.Green, wordle.Gray, wordle.Yellow, wordle.Gray, wordle.Green)
+	if p.Len() != 5 {
+		t.Fatalf("Len() = %d, want 5", p.Len())
+	}
+	if p.At(2) != wordle.Yellow {
+		t.Errorf("At(2) = %v, want Yellow", p.At(2))
+	}
+	if p.String() != "gxyxg" {
+		t.Errorf("String() = %s, want gxyxg", p)
+	}
+	if p.Emoji() != "🟩⬛🟨⬛🟩" {
+		t.Errorf("Emoji() = %s, want 🟩⬛🟨⬛🟩", p.Emoji())
+	}
+	if p.Solved() {
+		t.Errorf("Solved() = true, want false")
+	}
+	if !wordle.Score("crane", "crane").Solved() {
+		t.Errorf("Score of the answer is not solved")
+	}
+	if p.Index() >= 243 {
+		t.Errorf("Index() = %d, want < 243", p.Index())
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	tests := map[string]struct {
+		s       string
+		want    string
+		wantErr bool
+	}{
+		"letters":        {s: "xygxx", want: "xygxx"},
+		"upper case":     {s: "XYGXX", want: "xygxx"},
+		"dots":           {s: "..g.y", want: "xxgxy"},
+		"emoji":          {s: "⬛🟨🟩⬛⬛", want: "xygxx"},
+		"light mode":     {s: "⬜🟨🟩⬜⬜", want: "xygxx"},
+		"high contrast":  {s: "⬛🟦🟧⬛⬛", want: "xygxx"},
+		"emoji selector": {s: "⬛️🟨🟩⬛⬛", want: "xygxx"},
+		"empty":          {s: "", wantErr: true},
+		"bad tile":       {s: "xyzxx", wantErr: true},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := wordle.ParsePattern(test.s)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParsePattern(%q) error = %v, wantErr %v", test.s, err, test.wantErr)
+			}
+			if err == nil && got.String() != test.want {
+				t.Errorf("ParsePattern(%q) = %s, want %s", test.s, got, test.want)
+			}
+		})
+	}
+}
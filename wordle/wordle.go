@@ -1,59 +1,150 @@
 package wordle
 
 import (
-	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
+// WordLength is the number of letters in a word in Wordle, and the length used
+// when none is given.
+const WordLength = 5
+
+// MinWordLength and MaxWordLength bound the word lengths that can be played,
+// as in Lingo and other variants. Ranking guesses needs a bucket for every
+// pattern, 3^length of them, so the longest words are limited.
+const (
+	MinWordLength = 3
+	MaxWordLength = 10
+)
+
+// Len returns the number of letters in word. Letters are runes, so "ñandú"
+// has five.
+func Len(word string) int {
+	return utf8.RuneCountInString(word)
+}
+
+// CheckLength reports an error if words of n letters can't be played.
+func CheckLength(n int) error {
+	if n < MinWordLength || n > MaxWordLength {
+		return fmt.Errorf("word length %d is not between %d and %d", n, MinWordLength, MaxWordLength)
+	}
+	return nil
+}
+
 type LettersNotAt struct {
 	Position int // 0-based
-	Letters  []byte
+	Letters  []rune
 }
 
 type LetterAt struct {
 	Position int // 0-based
-	Letter   byte
+	Letter   rune
 }
 
-func MakePossibles(words []string, missed string, lettersAt []LetterAt, lettersNotAt []LettersNotAt) []string {
+// LetterCount constrains how many times a letter occurs in the word. It is
+// what a repeated letter in a guess tells you: "speed" scored yellow on the
+// first e and gray on the second means the word has exactly one e.
+type LetterCount struct {
+	Letter rune
+	Min    int  // the word has at least Min copies of Letter
+	Exact  bool // the word has exactly Min copies of Letter
+}
+
+func MakePossibles(words []string, c Constraints) []string {
+	known := c.knownCounts()
 	var possibles []string
 	for _, word := range words {
-		if CheckWord(word, missed, lettersAt, lettersNotAt) {
+		if checkWord(word, c, known) {
 			possibles = append(possibles, word)
 		}
 	}
 	return possibles
 }
 
-// CheckWord will take use missed, lettersAt, and lettersNotAt to determine if the word is a possible word.
-func CheckWord(word string, missed string, lettersAt []LetterAt, lettersNotAt []LettersNotAt) bool {
-	if wordContainsMissed(word, missed) {
+// CheckWord will use the constraints to determine if the word is a possible word.
+//
+// A missed letter that is also known to be in the word (through LettersAt,
+// LettersNotAt or Counts) does not reject every word containing it; instead it
+// caps the letter at the number of copies known to be there.
+func CheckWord(word string, c Constraints) bool {
+	return checkWord(word, c, c.knownCounts())
+}
+
+func checkWord(word string, c Constraints, known map[rune]int) bool {
+	if c.Length != 0 && Len(word) != c.Length {
 		return false
 	}
+	for _, letter := range c.Missed {
+		n, ok := known[letter]
+		if !ok {
+			if wordContainsMissed(word, string(letter)) {
+				return false
+			}
+			continue
+		}
+		if countLetter(word, letter) != n {
+			return false
+		}
+	}
 
-	for _, c := range lettersAt {
-		if !positionContainsLetter(word, c.Position, c.Letter) {
+	for _, at := range c.LettersAt {
+		if !positionContainsLetter(word, at.Position, at.Letter) {
 			return false
 		}
 	}
 
-	for _, c := range lettersNotAt {
-		for _, letter := range c.Letters {
+	for _, notAt := range c.LettersNotAt {
+		for _, letter := range notAt.Letters {
 			if !wordContainsMissed(word, string(letter)) {
 				return false
 			}
-			if positionContainsLetter(word, c.Position, letter) {
+			if positionContainsLetter(word, notAt.Position, letter) {
 				return false
 			}
 		}
 	}
+
+	for _, count := range c.Counts {
+		n := countLetter(word, count.Letter)
+		if n < count.Min || (count.Exact && n != count.Min) {
+			return false
+		}
+	}
 	return true
 }
 
+func countLetter(word string, letter rune) int {
+	return strings.Count(word, string(letter))
+}
+
 func wordContainsMissed(word, missed string) bool {
-	return bytes.ContainsAny([]byte(word), missed)
+	return strings.ContainsAny(word, missed)
+}
+
+// positionContainsLetter reports whether the letter at position in word is
+// letter. Positions count letters, not bytes.
+func positionContainsLetter(word string, position int, letter rune) bool {
+	if letter < utf8.RuneSelf && isASCII(word) {
+		return position < len(word) && rune(word[position]) == letter
+	}
+	i := 0
+	for _, r := range word {
+		if i == position {
+			return r == letter
+		}
+		i++
+	}
+	return false
 }
 
-func positionContainsLetter(word string, position int, letter byte) bool {
-	b := []byte(word)
-	return b[position] == letter
+// isASCII reports whether s has only ASCII characters, so each byte is a
+// letter.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
 }
@@ -12,6 +12,8 @@ func TestWordContainsMissed(t *testing.T) {
 		{Word: "hello", Missed: "h", Wanted: true},
 		{Word: "hello", Missed: "he", Wanted: true},
 		{Word: "hello", Missed: "b", Wanted: false},
+		{Word: "ñandú", Missed: "úx", Wanted: true},
+		{Word: "nandu", Missed: "ñú", Wanted: false},
 	}
 	for _, v := range tests {
 		if got := wordContainsMissed(v.Word, v.Missed); got != v.Wanted {
@@ -23,13 +25,16 @@ func TestWordContainsMissed(t *testing.T) {
 func TestPositionContainsLetter(t *testing.T) {
 	type testCase struct {
 		position int // 0-based
-		letter   byte
+		letter   rune
 		word     string
 		wanted   bool
 	}
 	tests := []testCase{
-		{position: 0, letter: byte('h'), word: "hello", wanted: true},
-		{position: 0, letter: byte('b'), word: "hello", wanted: false},
+		{position: 0, letter: 'h', word: "hello", wanted: true},
+		{position: 0, letter: 'b', word: "hello", wanted: false},
+		{position: 4, letter: 'ú', word: "ñandú", wanted: true},
+		{position: 1, letter: 'a', word: "ñandú", wanted: true},
+		{position: 1, letter: 'a', word: "ñ", wanted: false},
 	}
 	for _, v := range tests {
 		if got := positionContainsLetter(v.word, v.position, v.letter); got != v.wanted {
@@ -12,6 +12,7 @@ type testCase struct {
 	missed       string
 	lettersAt    []wordle.LetterAt
 	lettersNotAt []wordle.LettersNotAt
+	counts       []wordle.LetterCount
 }
 
 func TestKeepName(t *testing.T) {
@@ -21,13 +22,13 @@ func TestKeepName(t *testing.T) {
 			lettersAt: []wordle.LetterAt{{Position: 2, Letter: 'a'}}},
 		{want: true, word: "least",
 			lettersNotAt: []wordle.LettersNotAt{
-				{Position: 0, Letters: []byte{'a'}},
-				{Position: 4, Letters: []byte{'a'}},
+				{Position: 0, Letters: []rune{'a'}},
+				{Position: 4, Letters: []rune{'a'}},
 			},
 		},
 		{want: false, word: "least",
 			lettersNotAt: []wordle.LettersNotAt{
-				{Position: 2, Letters: []byte{'a'}},
+				{Position: 2, Letters: []rune{'a'}},
 			},
 		},
 		{want: false, word: "least",
@@ -36,10 +37,36 @@ func TestKeepName(t *testing.T) {
 				{Position: 3, Letter: 'v'},
 			},
 		},
+		// "speed" against "abide": the first e is yellow, the second gray.
+		{want: true, word: "abide", missed: "spe",
+			lettersNotAt: []wordle.LettersNotAt{
+				{Position: 2, Letters: []rune{'e'}},
+				{Position: 3, Letters: []rune{'e'}},
+				{Position: 4, Letters: []rune{'d'}},
+			},
+		},
+		{want: false, word: "geese", missed: "spe",
+			lettersNotAt: []wordle.LettersNotAt{
+				{Position: 2, Letters: []rune{'e'}},
+			},
+		},
+		{want: true, word: "abide",
+			counts: []wordle.LetterCount{{Letter: 'e', Min: 1, Exact: true}},
+		},
+		{want: false, word: "geese",
+			counts: []wordle.LetterCount{{Letter: 'e', Min: 2, Exact: true}},
+		},
+		{want: true, word: "geese",
+			counts: []wordle.LetterCount{{Letter: 'e', Min: 2}},
+		},
+		{want: false, word: "abide",
+			counts: []wordle.LetterCount{{Letter: 'e', Min: 2}},
+		},
 	}
 
 	for _, v := range foo {
-		actual := wordle.CheckWord(v.word, v.missed, v.lettersAt, v.lettersNotAt)
+		c := wordle.Constraints{Missed: v.missed, LettersAt: v.lettersAt, LettersNotAt: v.lettersNotAt, Counts: v.counts}
+		actual := wordle.CheckWord(v.word, c)
 		if actual != v.want {
 			fmt.Println("This test: ", v)
 			t.Fatalf(`keepWord(%s, %s) = %v, want %v`, v.word, v.missed, actual, v.want)